@@ -8,25 +8,33 @@ package main
 // @license.name MIT
 // @host localhost:3001
 // @BasePath /
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description "Bearer " followed by the ADMIN_TOKEN environment variable
 import (
+	"context"
 	"log"
 	"os"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/live"
+	"vlrggapi/internal/refresh"
 	"vlrggapi/internal/router"
+	"vlrggapi/internal/webhook"
 
 	// Explicitly import all handlers for swag to find them
-	_ "vlrggapi/internal/scrapers"
+	"vlrggapi/internal/scrapers"
 	_ "vlrggapi/docs"
 
 	"github.com/gofiber/swagger"
 	"go.uber.org/zap"
-	"sync"
 )
 
 func main() {
@@ -34,6 +42,31 @@ func main() {
 	loggerZap, _ := zap.NewProduction()
 	defer loggerZap.Sync()
 
+	// Shared upstream client (timeouts, retries, per-host limits)
+	fetch.SetDefault(fetch.New(fetch.ConfigFromEnv()))
+
+	// Background refresher: pre-scrape every registered endpoint into the
+	// store so requests never wait on vlr.gg
+	if refresh.Enabled() {
+		go refresh.Run(context.Background(), scrapers.Refreshables(), func(job, variant string, err error) {
+			loggerZap.Warn("refresh failed", zap.String("job", job), zap.String("variant", variant), zap.Error(err))
+		})
+	}
+
+	// Background live match poller feeding /vlr/live and its stream, sockets
+	// and webhooks
+	poller := live.New(live.IntervalFromEnv(), scrapers.FetchLiveMatches)
+	live.SetDefault(poller)
+	go poller.Run(context.Background())
+
+	// Outgoing webhooks for match lifecycle events
+	hooks, err := webhook.New(webhook.ConfigFromEnv(), scrapers.FetchTeamRegion)
+	if err != nil {
+		loggerZap.Fatal("Failed to load webhooks", zap.Error(err))
+	}
+	webhook.SetDefault(hooks)
+	go hooks.Run(context.Background(), poller)
+
 	app := fiber.New(fiber.Config{
 		AppName:      "vlrggapi",
 		ServerHeader: "vlrggapi",
@@ -55,45 +88,23 @@ func main() {
 		Expiration: 60 * 1000 * 1000 * 1000, // 1 minute in nanoseconds
 	}))
 
-	// Simple in-memory cache for GET requests (per endpoint+query)
-	type cacheEntry struct {
-		data      []byte
-		timestamp time.Time
+	// Response cache for GET requests (per endpoint+query), in memory or
+	// shared through Redis. Scrapers declare their own TTL and stale window;
+	// other routes use CACHE_TTL/CACHE_STALE
+	cacheConfig := cache.ConfigFromEnv()
+	responses, err := cache.Open(cacheConfig)
+	if err != nil {
+		loggerZap.Fatal("Failed to open cache", zap.String("backend", cacheConfig.Backend), zap.Error(err))
 	}
-	var (
-		cache      = make(map[string]cacheEntry)
-		cacheMutex sync.RWMutex
-		cacheTTL   = 30 * time.Second // cache duration
-	)
-	app.Use(func(c *fiber.Ctx) error {
-		if c.Method() != fiber.MethodGet {
-			return c.Next()
-		}
-		key := c.OriginalURL()
-		cacheMutex.RLock()
-		entry, found := cache[key]
-		cacheMutex.RUnlock()
-		if found && time.Since(entry.timestamp) < cacheTTL {
-			c.Response().Header.Set("X-Cache", "HIT")
-			return c.Send(entry.data)
-		}
-		// Capture response
-		err := c.Next()
-		if err != nil {
-			loggerZap.Error("handler error", zap.String("url", key), zap.Error(err))
-			return err
-		}
-		if c.Response().StatusCode() == fiber.StatusOK {
-			cacheMutex.Lock()
-			cache[key] = cacheEntry{
-				data:      c.Response().Body(),
-				timestamp: time.Now(),
-			}
-			cacheMutex.Unlock()
-			c.Response().Header.Set("X-Cache", "MISS")
-		}
-		return nil
-	})
+	defer responses.Close()
+	cache.SetDefault(responses)
+	defaultPolicy := cache.Policy{TTL: cacheConfig.TTL, Stale: cacheConfig.Stale}
+	// Outside the cache, so the age of stored and live data is current on
+	// every response
+	app.Use(scrapers.FreshnessAge())
+	app.Use(cache.Middleware(responses, defaultPolicy, cache.DefaultStats(), func(key string, err error) {
+		loggerZap.Error("cache middleware error", zap.String("url", key), zap.Error(err))
+	}))
 
 	// Register VLR router
 	router.RegisterVlrRoutes(app)
@@ -21,294 +21,366 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/vlr/events": {
+        "/vlr/admin/cache": {
             "get": {
-                "description": "Returns a list of upcoming or completed Valorant events",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns every cached response whose key (the request URL, e.g. /vlr/match?q=results) starts with prefix, sorted by key, with its route, size in bytes, age and policy in seconds, and whether it is fresh or stale.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "admin"
                 ],
-                "summary": "Get Valorant events",
+                "summary": "List cached responses",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "If true, return only upcoming events",
-                        "name": "upcoming",
+                        "type": "string",
+                        "description": "Only keys starting with this, e.g. /vlr/match",
+                        "name": "prefix",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_CacheKey"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/admin/cache/purge": {
+            "post": {
+                "security": [
                     {
-                        "type": "boolean",
-                        "description": "If true, return only completed events",
-                        "name": "completed",
-                        "in": "query"
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Removes one cached response by exact key, or every cached response whose key starts with prefix (e.g. /vlr/match). Background-refreshed endpoints also drop their stored data, on every replica sharing the cache, so the next request scrapes vlr.gg again: a key drops the stored copy of that query, a prefix every stored copy under it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Purge cached responses",
+                "parameters": [
+                    {
+                        "description": "Key or prefix to purge",
+                        "name": "purge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CachePurgeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.SegmentsResponse-models_CachePurgeResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/vlr/health": {
+        "/vlr/admin/cache/stats": {
             "get": {
-                "description": "Returns health status of the API and upstream sources",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns cache lookups per route pattern since process start: hits, stale hits served while refreshing, misses, and the share answered from the cache (hit_ratio, stale included). Counts are for the replica that answers, even when the cache itself is shared.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
                 ],
-                "summary": "Health check",
+                "summary": "Cache hit/miss statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.SegmentsResponse-models_CacheRouteStats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/vlr/live": {
+        "/vlr/admin/fetch": {
             "get": {
-                "description": "Returns live match scores from VLR.GG",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns how many upstream requests the scrapers made (requests), how many vlr.gg fetches they caused (fetches) and how many joined a fetch already in flight for the same URL instead of starting their own (deduplicated), and how many fetches vlr.gg answered with 304 Not Modified (not_modified). Counters are since process start.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "matches"
+                    "admin"
                 ],
-                "summary": "Get live Valorant match scores",
+                "summary": "Upstream fetch statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.SegmentsResponse-models_FetchStats"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/vlr/match": {
+        "/vlr/event/{id}": {
             "get": {
-                "description": "Returns upcoming scheduled matches or recent match results, depending on query params.",
+                "description": "Returns an event's stages with group standings and bracket trees, participating teams with seeds and the prize distribution",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "matches"
+                    "events"
                 ],
-                "summary": "Get Valorant match schedule or results",
+                "summary": "Get a single event",
                 "parameters": [
-                    {
-                        "type": "boolean",
-                        "description": "If true, return upcoming/scheduled matches (default: false)",
-                        "name": "schedule",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "If true, return match results (default: true)",
-                        "name": "results",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Number of pages to fetch (results only)",
-                        "name": "num_pages",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Start page (results only)",
-                        "name": "from_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "End page (results only)",
-                        "name": "to_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 3,
-                        "description": "Retry attempts per page (results only)",
-                        "name": "max_retries",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "default": 1,
-                        "description": "Delay between requests (seconds, results only)",
-                        "name": "request_delay",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "default": 30,
-                        "description": "HTTP timeout (seconds, results only)",
-                        "name": "timeout",
-                        "in": "query"
+                        "description": "vlr.gg event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.SegmentsResponse-models_EventDetail"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/vlr/news": {
+        "/vlr/event/{id}/matches": {
             "get": {
-                "description": "Returns a list of recent Valorant news articles",
+                "description": "Returns the matches of an event in schedule order, as the same rows as /vlr/match: upcoming and live matches by default (live ones with eta LIVE), completed matches with results=true",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "events"
+                ],
+                "summary": "Get the matches of an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vlr.gg event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return completed matches (models.MatchResult rows) instead of upcoming and live ones",
+                        "name": "results",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get latest Valorant news",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Upcoming and live matches. With results=true the segments are models.MatchResult rows instead (models.SegmentsResponse[models.MatchResult])",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.SegmentsResponse-models_ScheduledMatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/vlr/rankings": {
+        "/vlr/event/{id}/stats": {
             "get": {
-                "description": "Returns team rankings for a given region",
+                "description": "Returns the player stats table scoped to one event, with the same rows and filters as /vlr/stats but no minimum rounds or rating by default. An event_id query parameter naming a different event is rejected with 400.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "rankings"
+                    "stats"
                 ],
-                "summary": "Get Valorant team rankings",
+                "summary": "Get player statistics for an event",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vlr.gg event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "Region key (e.g. na, eu, ap, la, oce, kr, mn, gc, br, cn, jp, col)",
+                        "default": "all",
+                        "description": "Region key or all",
                         "name": "region",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "Agent name or all",
+                        "name": "agent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Map name or all",
+                        "name": "map",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum rounds played",
+                        "name": "min_rounds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum rating",
+                        "name": "min_rating",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.SegmentsResponse-models_PlayerStatLine"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/vlr/stats": {
+        "/vlr/events": {
             "get": {
-                "description": "Returns player statistics, filterable by region and timespan",
+                "description": "Returns a list of upcoming or completed Valorant events",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "events"
                 ],
-                "summary": "Get Valorant player statistics",
+                "summary": "Get Valorant events",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Region key (e.g. na, eu, ap, la, oce, kr, mn, gc, br, cn, jp, col)",
-                        "name": "region",
-                        "in": "query",
-                        "required": true
+                        "type": "boolean",
+                        "description": "If true, return only upcoming events",
+                        "name": "upcoming",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Timespan (e.g. all, 30 for 30 days)",
-                        "name": "timespan",
+                        "type": "boolean",
+                        "description": "If true, return only completed events",
+                        "name": "completed",
                         "in": "query"
                     }
                 ],
@@ -316,30 +388,3240 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.SegmentsResponse-models_Event"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
+        },
+        "/vlr/health": {
+            "get": {
+                "description": "Returns health status of the API and upstream sources",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/models.SiteHealth"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/live": {
+            "get": {
+                "description": "Returns live match scores from VLR.GG",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Get live Valorant match scores",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_LiveMatch"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/live/stream": {
+            "get": {
+                "description": "Server-Sent Events feed of live match changes. A \"snapshot\" event with every live match is sent first, followed by \"match_started\", \"round_won\", \"map_ended\" and \"match_ended\" events as the background poller sees them. Each event's data is a models.LiveEvent JSON object.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Stream live match score changes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LiveEvent"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/live/ws": {
+            "get": {
+                "description": "WebSocket endpoint for following specific live matches. Send {\"action\":\"subscribe\"} or {\"action\":\"unsubscribe\"} with any of match_id, event_id and team_id. The server answers with a models.LiveSocketReply (\"subscribed\", \"unsubscribed\" or \"error\"), pushes models.LiveEvent messages (\"snapshot\", \"match_started\", \"round_won\", \"map_ended\", \"match_ended\") for subscribed matches and sends a \"heartbeat\" every 15 seconds. Clients that fall behind are closed with code 1013.",
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Subscribe to live match changes over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "websocket",
+                        "name": "Upgrade",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "426": {
+                        "description": "Upgrade Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/match": {
+            "get": {
+                "description": "Returns upcoming scheduled matches or recent match results, depending on query params.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Get Valorant match schedule or results",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "If true, return upcoming/scheduled matches (default: false)",
+                        "name": "schedule",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "If true, return match results (default: true)",
+                        "name": "results",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Number of pages to fetch (results only)",
+                        "name": "num_pages",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start page (results only)",
+                        "name": "from_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End page (results only)",
+                        "name": "to_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Retry attempts per page (results only)",
+                        "name": "max_retries",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 1,
+                        "description": "Delay between requests (seconds, results only)",
+                        "name": "request_delay",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "HTTP timeout (seconds, results only)",
+                        "name": "timeout",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_MatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/match/{id}": {
+            "get": {
+                "description": "Returns series metadata, the map veto, per-map scores with attack/defense splits and per-player scoreboards for each map and the whole series",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Get a single match",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vlr.gg match ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated extra tabs to fetch: economy, performance",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_MatchDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/match/{id}/rounds": {
+            "get": {
+                "description": "Returns an ordered list of rounds per map with the winning team, side, win condition and running score",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matches"
+                ],
+                "summary": "Get the round timeline of a match",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vlr.gg match ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_MapRounds"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/news": {
+            "get": {
+                "description": "Returns a list of recent Valorant news articles, served from the background-refreshed store",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get latest Valorant news",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_NewsArticle"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/player/{id}": {
+            "get": {
+                "description": "Returns a player's name, country, socials, current and past teams, agent stats over a timespan and recent matches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Get a player profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vlr.gg player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agent stats window: 30d, 60d, 90d (default) or all",
+                        "name": "timespan",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_PlayerProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/rankings": {
+            "get": {
+                "description": "Returns team rankings for a given region, served from the background-refreshed store",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rankings"
+                ],
+                "summary": "Get Valorant team rankings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Region key (na, eu, br, ap, kr, cn, jp, la, la-s, la-n, oce, mn, gc, col) or rankings slug (e.g. north-america)",
+                        "name": "region",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RankingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/stats": {
+            "get": {
+                "description": "Returns player statistics, filterable by every filter of the vlr.gg stats page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get Valorant player statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "Region key (e.g. na, eu, ap, la, oce, kr, mn, gc, br, cn, jp, col) or all",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "Two-letter country code or all",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "Agent name (e.g. jett, kayo) or all",
+                        "name": "agent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Map name (e.g. ascent, lotus) or all",
+                        "name": "map",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "vlr.gg map ID or all; cannot be combined with map",
+                        "name": "map_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "vlr.gg event group ID or all",
+                        "name": "event_group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "vlr.gg event ID or all",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 200,
+                        "description": "Minimum rounds played",
+                        "name": "min_rounds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1550,
+                        "description": "Minimum rating",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "Days (30, 60, 90) or all",
+                        "name": "timespan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of a custom date range (YYYY-MM-DD); requires date_end",
+                        "name": "date_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of a custom date range (YYYY-MM-DD); requires date_start",
+                        "name": "date_end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_PlayerStatLine"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/team/{id}": {
+            "get": {
+                "description": "Returns a team's roster with roles, region, current ranking and rating, rating history, total winnings, recent results and upcoming matches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Get a team profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "vlr.gg team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_TeamProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns every registered webhook, oldest first. Secrets are not included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_Webhook"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Registers a URL to receive signed JSON POSTs when matches go live (match_started), maps end (map_ended) or results post (match_ended). Optional team_ids, event_ids and regions restrict which matches are sent. The secret, generated if not given, is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook registration",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns a single registered webhook without its secret.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_Webhook"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Removes a webhook. Pending retries for it are dropped.",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns the most recent delivery attempts for a webhook, newest first, with status code, error, duration and the next retry time if one is scheduled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook's delivery log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_WebhookDelivery"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vlr/webhooks/{id}/test": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "POSTs a signed \"ping\" notification to the webhook once, without retries, and returns the logged delivery. A receiver error is reported in the delivery, not as an error status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Send a test ping to a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SegmentsResponse-models_WebhookDelivery"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AgentStatLine": {
+            "type": "object",
+            "properties": {
+                "agent": {
+                    "type": "string"
+                },
+                "assists": {
+                    "type": "integer"
+                },
+                "assists_per_round": {
+                    "type": "number"
+                },
+                "average_combat_score": {
+                    "type": "number"
+                },
+                "average_damage_per_round": {
+                    "type": "number"
+                },
+                "deaths": {
+                    "type": "integer"
+                },
+                "first_deaths": {
+                    "type": "integer"
+                },
+                "first_deaths_per_round": {
+                    "type": "number"
+                },
+                "first_kills": {
+                    "type": "integer"
+                },
+                "first_kills_per_round": {
+                    "type": "number"
+                },
+                "kill_assists_survived_traded": {
+                    "type": "number"
+                },
+                "kill_deaths": {
+                    "type": "number"
+                },
+                "kills": {
+                    "type": "integer"
+                },
+                "kills_per_round": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "rounds_played": {
+                    "type": "integer"
+                },
+                "usage_count": {
+                    "type": "integer"
+                },
+                "usage_percentage": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Bracket": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BracketRound"
+                    }
+                }
+            }
+        },
+        "models.BracketMatch": {
+            "type": "object",
+            "properties": {
+                "match_id": {
+                    "type": "integer"
+                },
+                "match_page": {
+                    "type": "string"
+                },
+                "team1": {
+                    "$ref": "#/definitions/models.BracketSlot"
+                },
+                "team2": {
+                    "$ref": "#/definitions/models.BracketSlot"
+                }
+            }
+        },
+        "models.BracketRound": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BracketMatch"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BracketSlot": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "winner": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.BuyStats": {
+            "type": "object",
+            "properties": {
+                "played": {
+                    "type": "integer"
+                },
+                "win_rate": {
+                    "type": "number"
+                },
+                "won": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CacheKey": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "stale": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "stored_at": {
+                    "type": "string"
+                },
+                "ttl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CachePurgeRequest": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CachePurgeResult": {
+            "type": "object",
+            "properties": {
+                "purged": {
+                    "type": "integer"
+                },
+                "scrapers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CacheRouteStats": {
+            "type": "object",
+            "properties": {
+                "hit_ratio": {
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "stale": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Country": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Duel": {
+            "type": "object",
+            "properties": {
+                "deaths": {
+                    "type": "integer"
+                },
+                "kills": {
+                    "type": "integer"
+                },
+                "opponent": {
+                    "type": "string"
+                },
+                "player": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EconomyRound": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "type": "integer"
+                },
+                "team1": {
+                    "$ref": "#/definitions/models.RoundBuy"
+                },
+                "team2": {
+                    "$ref": "#/definitions/models.RoundBuy"
+                },
+                "winner": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Event": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "description": "Country is the event location; vlr.gg uses region flags for online\nand multi-country events.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Country"
+                        }
+                    ]
+                },
+                "dates": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "prize": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "thumb": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url_path": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EventDetail": {
+            "type": "object",
+            "properties": {
+                "dates": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prize": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "prizes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PrizePlacement"
+                    }
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "stages": {
+                    "description": "Stages are in page order, e.g. \"Group Stage\" then \"Playoffs\".",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventStage"
+                    }
+                },
+                "subtitle": {
+                    "type": "string"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventTeam"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EventStage": {
+            "type": "object",
+            "properties": {
+                "brackets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Bracket"
+                    }
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupStandings"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EventTeam": {
+            "type": "object",
+            "properties": {
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seed": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FetchStats": {
+            "type": "object",
+            "properties": {
+                "deduplicated": {
+                    "type": "integer"
+                },
+                "fetches": {
+                    "type": "integer"
+                },
+                "in_flight": {
+                    "type": "integer"
+                },
+                "not_modified": {
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GroupStanding": {
+            "type": "object",
+            "properties": {
+                "losses": {
+                    "type": "integer"
+                },
+                "map_diff": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "round_diff": {
+                    "type": "integer"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "ties": {
+                    "type": "integer"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GroupStandings": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupStanding"
+                    }
+                }
+            }
+        },
+        "models.KillMatrix": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "object",
+                "additionalProperties": {
+                    "$ref": "#/definitions/models.Duel"
+                }
+            }
+        },
+        "models.LiveEvent": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "match": {
+                    "description": "Match is the match state after the change; for match_ended it is the\nlast state seen while live.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LiveMatch"
+                        }
+                    ]
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "description": "Matches is the full list of live matches, only set on snapshot events\n(and omitted there when nothing is live).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LiveMatch"
+                    }
+                },
+                "team": {
+                    "description": "Team is 1 or 2 for the team that won the round, map or match, 0 when\nnot applicable or unknown.",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type is \"snapshot\", \"match_started\", \"round_won\", \"map_ended\" or\n\"match_ended\".",
+                    "type": "string"
+                }
+            }
+        },
+        "models.LiveMatch": {
+            "type": "object",
+            "properties": {
+                "country1": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "country2": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "current_map": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "map_number": {
+                    "type": "integer"
+                },
+                "match_event": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "match_page": {
+                    "type": "string"
+                },
+                "match_series": {
+                    "type": "string"
+                },
+                "match_slug": {
+                    "type": "string"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "score1": {
+                    "type": "integer"
+                },
+                "score2": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "team1": {
+                    "type": "string"
+                },
+                "team1_id": {
+                    "type": "integer"
+                },
+                "team1_logo": {
+                    "type": "string"
+                },
+                "team1_round_ct": {
+                    "type": "integer"
+                },
+                "team1_round_t": {
+                    "type": "integer"
+                },
+                "team2": {
+                    "type": "string"
+                },
+                "team2_id": {
+                    "type": "integer"
+                },
+                "team2_logo": {
+                    "type": "string"
+                },
+                "team2_round_ct": {
+                    "type": "integer"
+                },
+                "team2_round_t": {
+                    "type": "integer"
+                },
+                "time_until_match": {
+                    "type": "string"
+                },
+                "unix_timestamp": {
+                    "description": "UnixTimestamp is the scheduled start in seconds since the epoch.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MapEconomy": {
+            "type": "object",
+            "properties": {
+                "game_id": {
+                    "type": "integer"
+                },
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EconomyRound"
+                    }
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamEconomy"
+                    }
+                }
+            }
+        },
+        "models.MapPerformance": {
+            "type": "object",
+            "properties": {
+                "first_kills": {
+                    "$ref": "#/definitions/models.KillMatrix"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "kills": {
+                    "description": "Kills, FirstKills and OperatorKills are the all-kills, first-kills and\noperator-kills matrices.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.KillMatrix"
+                        }
+                    ]
+                },
+                "operator_kills": {
+                    "$ref": "#/definitions/models.KillMatrix"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerPerformance"
+                    }
+                }
+            }
+        },
+        "models.MapRounds": {
+            "type": "object",
+            "properties": {
+                "game_id": {
+                    "type": "integer"
+                },
+                "map": {
+                    "type": "string"
+                },
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Round"
+                    }
+                },
+                "team1": {
+                    "type": "string"
+                },
+                "team2": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MapScore": {
+            "type": "object",
+            "properties": {
+                "attack": {
+                    "type": "integer"
+                },
+                "defense": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "overtime": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MapVeto": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "map": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                },
+                "team": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MatchDetail": {
+            "type": "object",
+            "properties": {
+                "best_of": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/models.MatchEventInfo"
+                },
+                "maps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MatchMap"
+                    }
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "match_slug": {
+                    "type": "string"
+                },
+                "patch": {
+                    "type": "string"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "series_players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerGame"
+                    }
+                },
+                "status": {
+                    "description": "Status is the header note, e.g. \"final\", \"live\" or a countdown.",
+                    "type": "string"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MatchTeam"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                },
+                "vetoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MapVeto"
+                    }
+                }
+            }
+        },
+        "models.MatchEventInfo": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "series": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MatchMap": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "economy": {
+                    "description": "Economy is only filled when requested with ?include=economy.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MapEconomy"
+                        }
+                    ]
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "map": {
+                    "type": "string"
+                },
+                "performance": {
+                    "description": "Performance is only filled when requested with ?include=performance.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MapPerformance"
+                        }
+                    ]
+                },
+                "picked_by": {
+                    "type": "string"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerGame"
+                    }
+                },
+                "team1": {
+                    "$ref": "#/definitions/models.MapScore"
+                },
+                "team2": {
+                    "$ref": "#/definitions/models.MapScore"
+                },
+                "winner": {
+                    "description": "Winner is 1 or 2 for the winning team, 0 if the map is unfinished.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MatchResult": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "description": "CompletedAt is derived from the \"... ago\" label at scrape time.",
+                    "type": "string"
+                },
+                "country1": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "country2": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "match_page": {
+                    "type": "string"
+                },
+                "match_slug": {
+                    "type": "string"
+                },
+                "page_number": {
+                    "type": "integer"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "round_info": {
+                    "type": "string"
+                },
+                "score1": {
+                    "type": "integer"
+                },
+                "score2": {
+                    "type": "integer"
+                },
+                "team1": {
+                    "type": "string"
+                },
+                "team2": {
+                    "type": "string"
+                },
+                "time_completed": {
+                    "type": "string"
+                },
+                "tournament_icon": {
+                    "type": "string"
+                },
+                "tournament_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MatchTeam": {
+            "type": "object",
+            "properties": {
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "winner": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Money": {
+            "type": "object",
+            "properties": {
+                "amount_cents": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NewsArticle": {
+            "type": "object",
+            "properties": {
+                "article_id": {
+                    "type": "integer"
+                },
+                "author": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url_path": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerGame": {
+            "type": "object",
+            "properties": {
+                "acs": {
+                    "type": "number"
+                },
+                "adr": {
+                    "type": "number"
+                },
+                "agents": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "assists": {
+                    "type": "integer"
+                },
+                "deaths": {
+                    "type": "integer"
+                },
+                "first_deaths": {
+                    "type": "integer"
+                },
+                "first_kills": {
+                    "type": "integer"
+                },
+                "fk_diff": {
+                    "type": "integer"
+                },
+                "headshot_percentage": {
+                    "type": "number"
+                },
+                "kast": {
+                    "type": "number"
+                },
+                "kd_diff": {
+                    "type": "integer"
+                },
+                "kills": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "team": {
+                    "type": "integer"
+                },
+                "team_tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerPerformance": {
+            "type": "object",
+            "properties": {
+                "agent": {
+                    "type": "string"
+                },
+                "clutches": {
+                    "description": "Clutches counts won clutches keyed \"1v1\"..\"1v5\".",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "defuses": {
+                    "type": "integer"
+                },
+                "econ": {
+                    "type": "integer"
+                },
+                "multikills": {
+                    "description": "Multikills counts rounds with 2, 3, 4 and 5 kills, keyed \"2k\"..\"5k\".",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "plants": {
+                    "type": "integer"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "team_tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerProfile": {
+            "type": "object",
+            "properties": {
+                "agents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgentStatLine"
+                    }
+                },
+                "avatar": {
+                    "type": "string"
+                },
+                "country": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "current_teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamStint"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "past_teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamStint"
+                    }
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "real_name": {
+                    "type": "string"
+                },
+                "recent_matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecentMatch"
+                    }
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "socials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SocialLink"
+                    }
+                },
+                "timespan": {
+                    "description": "Timespan is the window the agent stats cover: \"30d\", \"60d\", \"90d\" or \"all\".",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlayerStatLine": {
+            "type": "object",
+            "properties": {
+                "agents": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "assists_per_round": {
+                    "type": "number"
+                },
+                "average_combat_score": {
+                    "type": "number"
+                },
+                "average_damage_per_round": {
+                    "type": "number"
+                },
+                "clutch_success_percentage": {
+                    "type": "number"
+                },
+                "first_deaths_per_round": {
+                    "type": "number"
+                },
+                "first_kills_per_round": {
+                    "type": "number"
+                },
+                "headshot_percentage": {
+                    "type": "number"
+                },
+                "kill_assists_survived_traded": {
+                    "type": "number"
+                },
+                "kill_deaths": {
+                    "type": "number"
+                },
+                "kills_per_round": {
+                    "type": "number"
+                },
+                "org": {
+                    "type": "string"
+                },
+                "player": {
+                    "type": "string"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "player_slug": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "rounds_played": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PrizePlacement": {
+            "type": "object",
+            "properties": {
+                "place": {
+                    "type": "string"
+                },
+                "prize": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "team": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Ranking": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "earnings": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "last_played": {
+                    "type": "string"
+                },
+                "last_played_match_id": {
+                    "type": "integer"
+                },
+                "last_played_team": {
+                    "type": "string"
+                },
+                "last_played_team_logo": {
+                    "type": "string"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "record": {
+                    "$ref": "#/definitions/models.Record"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_slug": {
+                    "type": "string"
+                },
+                "team_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RankingsResponse": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Ranking"
+                    }
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RatingPoint": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.RecentMatch": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "match_page": {
+                    "type": "string"
+                },
+                "match_slug": {
+                    "type": "string"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "result": {
+                    "description": "Result is \"win\", \"loss\" or empty for draws and unplayed matches.",
+                    "type": "string"
+                },
+                "score1": {
+                    "type": "integer"
+                },
+                "score2": {
+                    "type": "integer"
+                },
+                "series": {
+                    "type": "string"
+                },
+                "team1": {
+                    "type": "string"
+                },
+                "team2": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Record": {
+            "type": "object",
+            "properties": {
+                "losses": {
+                    "type": "integer"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ResultsMeta": {
+            "type": "object",
+            "properties": {
+                "failed_pages": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "page_range": {
+                    "type": "string"
+                },
+                "successful_pages": {
+                    "type": "integer"
+                },
+                "total_matches": {
+                    "type": "integer"
+                },
+                "total_pages_requested": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RosterMember": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "avatar": {
+                    "type": "string"
+                },
+                "captain": {
+                    "type": "boolean"
+                },
+                "country": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "real_name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "staff": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Round": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "type": "integer"
+                },
+                "team1_score": {
+                    "type": "integer"
+                },
+                "team2_score": {
+                    "type": "integer"
+                },
+                "win_type": {
+                    "description": "WinType is \"elimination\", \"detonated\", \"defused\" or \"time\".",
+                    "type": "string"
+                },
+                "winner": {
+                    "type": "integer"
+                },
+                "winner_side": {
+                    "description": "WinnerSide is \"attack\" or \"defense\".",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RoundBuy": {
+            "type": "object",
+            "properties": {
+                "bank": {
+                    "type": "integer"
+                },
+                "buy_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ScheduledMatch": {
+            "type": "object",
+            "properties": {
+                "country1": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "country2": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "eta": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "match_page": {
+                    "type": "string"
+                },
+                "match_slug": {
+                    "type": "string"
+                },
+                "match_time": {
+                    "type": "string"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "series": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "description": "StartsAt is the start time derived from ETA at scrape time.",
+                    "type": "string"
+                },
+                "team1": {
+                    "type": "string"
+                },
+                "team2": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Segments-models_CacheKey": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CacheKey"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_CachePurgeResult": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CachePurgeResult"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_CacheRouteStats": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CacheRouteStats"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_Event": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Event"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_EventDetail": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventDetail"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_FetchStats": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FetchStats"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_LiveMatch": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LiveMatch"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_MapRounds": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MapRounds"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_MatchDetail": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MatchDetail"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_MatchResult": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MatchResult"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_NewsArticle": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NewsArticle"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_PlayerProfile": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerProfile"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_PlayerStatLine": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerStatLine"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_ScheduledMatch": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScheduledMatch"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_TeamProfile": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamProfile"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_Webhook": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Webhook"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Segments-models_WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.ResultsMeta"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookDelivery"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_CacheKey": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_CacheKey"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_CachePurgeResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_CachePurgeResult"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_CacheRouteStats": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_CacheRouteStats"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_Event": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_Event"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_EventDetail": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_EventDetail"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_FetchStats": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_FetchStats"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_LiveMatch": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_LiveMatch"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_MapRounds": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_MapRounds"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_MatchDetail": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_MatchDetail"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_MatchResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_MatchResult"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_NewsArticle": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_NewsArticle"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_PlayerProfile": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_PlayerProfile"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_PlayerStatLine": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_PlayerStatLine"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_ScheduledMatch": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_ScheduledMatch"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_TeamProfile": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_TeamProfile"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_Webhook": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_Webhook"
+                }
+            }
+        },
+        "models.SegmentsResponse-models_WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Segments-models_WebhookDelivery"
+                }
+            }
+        },
+        "models.SiteHealth": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SocialLink": {
+            "type": "object",
+            "properties": {
+                "handle": {
+                    "type": "string"
+                },
+                "platform": {
+                    "description": "Platform is derived from the link host, e.g. \"twitter\" or \"twitch\".",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TeamEconomy": {
+            "type": "object",
+            "properties": {
+                "eco": {
+                    "$ref": "#/definitions/models.BuyStats"
+                },
+                "full_buy": {
+                    "$ref": "#/definitions/models.BuyStats"
+                },
+                "pistol_won": {
+                    "type": "integer"
+                },
+                "semi_buy": {
+                    "$ref": "#/definitions/models.BuyStats"
+                },
+                "semi_eco": {
+                    "$ref": "#/definitions/models.BuyStats"
+                },
+                "team": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TeamProfile": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "$ref": "#/definitions/models.Country"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "rating_history": {
+                    "description": "RatingHistory is the team's rating after each completed match, newest\nfirst.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RatingPoint"
+                    }
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "recent_results": {
+                    "description": "RecentResults are completed matches, newest first; Team1 is this team.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecentMatch"
+                    }
+                },
+                "region": {
+                    "description": "Region is the ranking region the team is listed under, e.g. \"Europe\".",
+                    "type": "string"
+                },
+                "roster": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RosterMember"
+                    }
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "socials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SocialLink"
+                    }
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "total_winnings": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "upcoming_matches": {
+                    "description": "UpcomingMatches are scheduled matches without a score yet.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecentMatch"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TeamStint": {
+            "type": "object",
+            "properties": {
+                "joined": {
+                    "type": "string"
+                },
+                "left": {
+                    "type": "string"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "raw": {
+                    "description": "Raw holds the original scraped strings of typed fields, keyed by JSON name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "role": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "events": {
+                    "description": "Events are the notification types to send: \"match_started\",\n\"map_ended\" and/or \"match_ended\".",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "regions": {
+                    "description": "Regions are region keys as accepted by /vlr/rankings, e.g. \"eu\". A\nmatch is in a region if either team is ranked there.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Secret signs every delivery. It is only returned when the webhook is\ncreated.",
+                    "type": "string"
+                },
+                "team_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "attempt": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "next_retry": {
+                    "description": "NextRetry is when the notification will be tried again, if it will.",
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "StatusCode is the receiver's response status, 0 if none was received.",
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "event_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "team_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "\"Bearer \" followed by the ADMIN_TOKEN environment variable",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,211 @@
+// Package cache stores rendered API responses so repeated GETs skip the
+// handler entirely. The backend is chosen by configuration: a size-bounded
+// in-memory LRU for a single instance, or Redis so that replicas share one
+// cache.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a cached response together with the policy it was stored under.
+// ETag and LastModified are the validators clients revalidate against; Route
+// is the route pattern that produced it, for statistics.
+type Entry struct {
+	Body         []byte        `json:"body"`
+	Route        string        `json:"route,omitempty"`
+	ContentType  string        `json:"content_type"`
+	ETag         string        `json:"etag"`
+	LastModified time.Time     `json:"last_modified"`
+	StoredAt     time.Time     `json:"stored_at"`
+	TTL          time.Duration `json:"ttl"`
+	Stale        time.Duration `json:"stale"`
+}
+
+// Size is the number of bytes an entry accounts for in a size-bounded cache.
+func (e Entry) Size() int64 {
+	return int64(len(e.Body) + len(e.ContentType))
+}
+
+// Info describes e, stored under key, without its body.
+func (e Entry) Info(key string) KeyInfo {
+	return KeyInfo{Key: key, Route: e.Route, Size: e.Size(), StoredAt: e.StoredAt, TTL: e.TTL, Stale: e.Stale}
+}
+
+// KeyInfo describes a cached entry for listings.
+type KeyInfo struct {
+	Key      string
+	Route    string
+	Size     int64
+	StoredAt time.Time
+	TTL      time.Duration
+	Stale    time.Duration
+}
+
+// Age is how long ago the entry was stored, as seen at now.
+func (e Entry) Age(now time.Time) time.Duration {
+	return now.Sub(e.StoredAt)
+}
+
+// ETag returns a strong entity tag for body.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// Policy is how long a route's responses are cached: fresh for TTL, then
+// served stale for up to Stale more while a background request refreshes
+// them. A zero TTL disables caching.
+type Policy struct {
+	TTL   time.Duration
+	Stale time.Duration
+}
+
+// CacheControl returns the Cache-Control header value for p.
+func (p Policy) CacheControl() string {
+	if p.Stale <= 0 {
+		return fmt.Sprintf("public, max-age=%d", int64(p.TTL/time.Second))
+	}
+	return fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", int64(p.TTL/time.Second), int64(p.Stale/time.Second))
+}
+
+// PolicyFromEnv returns def with CACHE_<NAME>_TTL and CACHE_<NAME>_STALE
+// environment overrides applied, e.g. CACHE_LIVE_TTL=5s. Invalid values are
+// ignored.
+func PolicyFromEnv(name string, def Policy) Policy {
+	prefix := "CACHE_" + strings.ToUpper(name)
+	if d, err := time.ParseDuration(os.Getenv(prefix + "_TTL")); err == nil && d >= 0 {
+		def.TTL = d
+	}
+	if d, err := time.ParseDuration(os.Getenv(prefix + "_STALE")); err == nil && d >= 0 {
+		def.Stale = d
+	}
+	return def
+}
+
+// Cache is a response cache backend. Implementations are safe for
+// concurrent use. Get reports a miss, not an error, for missing or expired
+// keys.
+type Cache interface {
+	Get(ctx context.Context, key string) (Entry, bool, error)
+	Set(ctx context.Context, key string, e Entry, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	// Keys lists the unexpired entries whose key starts with prefix.
+	Keys(ctx context.Context, prefix string) ([]KeyInfo, error)
+	// DeletePrefix removes every key starting with prefix and returns how
+	// many there were.
+	DeletePrefix(ctx context.Context, prefix string) (int, error)
+	// Close releases background goroutines and connections.
+	Close() error
+}
+
+// Shared reports whether c is shared with other replicas, so that state a
+// replica keeps beside the cache has to be invalidated through it. Backends
+// say so with a Shared method.
+func Shared(c Cache) bool {
+	s, ok := c.(interface{ Shared() bool })
+	return ok && s.Shared()
+}
+
+// Config selects and sizes the backend.
+type Config struct {
+	// Backend is "memory" or "redis".
+	Backend string
+	// TTL is how long a response stays fresh on routes without their own
+	// policy.
+	TTL time.Duration
+	// Stale is how long after TTL such a response is still served while it
+	// is refreshed in the background.
+	Stale time.Duration
+	// MaxBytes caps the total size of the memory backend.
+	MaxBytes int64
+	// MaxEntries caps the number of entries in the memory backend.
+	MaxEntries int
+	// SweepInterval is how often the memory backend drops expired entries.
+	SweepInterval time.Duration
+	// RedisURL is the redis:// or rediss:// URL of the redis backend.
+	RedisURL string
+	// RedisPrefix namespaces keys in a shared Redis.
+	RedisPrefix string
+}
+
+// DefaultConfig returns the configuration used when nothing is overridden.
+func DefaultConfig() Config {
+	return Config{
+		Backend:       "memory",
+		TTL:           30 * time.Second,
+		Stale:         30 * time.Second,
+		MaxBytes:      64 << 20,
+		MaxEntries:    10000,
+		SweepInterval: time.Minute,
+		RedisURL:      "redis://localhost:6379/0",
+		RedisPrefix:   "vlrggapi:cache:",
+	}
+}
+
+// ConfigFromEnv returns DefaultConfig with any CACHE_* and REDIS_URL
+// environment overrides applied. Invalid values are ignored.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	if b := strings.ToLower(os.Getenv("CACHE_BACKEND")); b != "" {
+		cfg.Backend = b
+	}
+	if d, err := time.ParseDuration(os.Getenv("CACHE_TTL")); err == nil && d > 0 {
+		cfg.TTL = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("CACHE_STALE")); err == nil && d >= 0 {
+		cfg.Stale = d
+	}
+	if n, err := strconv.ParseInt(os.Getenv("CACHE_MAX_BYTES"), 10, 64); err == nil && n > 0 {
+		cfg.MaxBytes = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("CACHE_MAX_ENTRIES")); err == nil && n > 0 {
+		cfg.MaxEntries = n
+	}
+	if u := os.Getenv("REDIS_URL"); u != "" {
+		cfg.RedisURL = u
+	}
+	if p := os.Getenv("CACHE_REDIS_PREFIX"); p != "" {
+		cfg.RedisPrefix = p
+	}
+	return cfg
+}
+
+var (
+	defaultMu    sync.RWMutex
+	defaultCache Cache
+)
+
+// Default returns the process-wide response cache, or nil if none was set.
+func Default() Cache {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultCache
+}
+
+// SetDefault replaces the process-wide response cache. It is intended to be
+// called once during startup.
+func SetDefault(c Cache) {
+	defaultMu.Lock()
+	defaultCache = c
+	defaultMu.Unlock()
+}
+
+// Open returns the backend selected by cfg.
+func Open(cfg Config) (Cache, error) {
+	switch cfg.Backend {
+	case "", "memory":
+		return NewMemory(cfg.MaxBytes, cfg.MaxEntries, cfg.SweepInterval), nil
+	case "redis":
+		return NewRedis(cfg.RedisURL, cfg.RedisPrefix)
+	}
+	return nil, fmt.Errorf("cache: unknown backend %q (want memory or redis)", cfg.Backend)
+}
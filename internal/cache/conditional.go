@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// notModified reports whether the request's conditional headers match a
+// response with the given validators. If-None-Match takes precedence over
+// If-Modified-Since, as in RFC 9110. A zero lastModified never matches.
+func notModified(ctx *fiber.Ctx, etag string, lastModified time.Time) bool {
+	if inm := ctx.Get(fiber.HeaderIfNoneMatch); inm != "" {
+		return etagMatches(inm, etag)
+	}
+	ims := ctx.Get(fiber.HeaderIfModifiedSince)
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// HTTP dates have one-second resolution
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches applies the weak comparison of If-None-Match: a list of
+// entity tags, or "*" for any.
+func etagMatches(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// sendNotModified turns the response into a bodiless 304, keeping the
+// validator and caching headers already set.
+func sendNotModified(ctx *fiber.Ctx) {
+	ctx.Status(fiber.StatusNotModified)
+	ctx.Response().ResetBody()
+	ctx.Response().Header.Del(fiber.HeaderContentType)
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+func TestETagMatches(t *testing.T) {
+	const etag = `"abc"`
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{`"abc"`, true},
+		{`"abd"`, false},
+		{`*`, true},
+		{` * `, true},
+		// Weak comparison: W/ is ignored on either side
+		{`W/"abc"`, true},
+		{`"xyz", "abc"`, true},
+		{`"xyz",W/"abc"`, true},
+		{`"xyz", "uvw"`, false},
+		{`"xyz", *`, false},
+		{`abc`, false},
+		{`,`, false},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, etag); got != tt.want {
+			t.Errorf("etagMatches(%q, %q) = %v, want %v", tt.header, etag, got, tt.want)
+		}
+	}
+	if !etagMatches(`"abc"`, `W/"abc"`) {
+		t.Error("a weak stored ETag should match its strong form")
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	const etag = `"abc"`
+	modified := time.Date(2026, 10, 17, 18, 0, 0, 500e6, time.UTC)
+	at := func(t time.Time) string { return t.Format(http.TimeFormat) }
+	tests := []struct {
+		name         string
+		ifNoneMatch  string
+		ifModSince   string
+		lastModified time.Time
+		want         bool
+	}{
+		{"no conditional headers", "", "", modified, false},
+		{"etag matches", etag, "", modified, true},
+		{"etag differs", `"old"`, "", modified, false},
+		{"not modified since", "", at(modified), modified, true},
+		{"modified within the same second", "", at(modified.Truncate(time.Second)), modified, true},
+		{"modified after", "", at(modified.Add(-time.Second)), modified, false},
+		{"modified before", "", at(modified.Add(time.Hour)), modified, true},
+		{"unparsable date", "", "yesterday", modified, false},
+		{"no last modified", "", at(modified), time.Time{}, false},
+		// If-None-Match wins, whichever way If-Modified-Since would go
+		{"etag differs, date would match", `"old"`, at(modified.Add(time.Hour)), modified, false},
+		{"etag matches, date would not", etag, at(modified.Add(-time.Hour)), modified, true},
+	}
+	app := fiber.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fctx := &fasthttp.RequestCtx{}
+			if tt.ifNoneMatch != "" {
+				fctx.Request.Header.Set(fiber.HeaderIfNoneMatch, tt.ifNoneMatch)
+			}
+			if tt.ifModSince != "" {
+				fctx.Request.Header.Set(fiber.HeaderIfModifiedSince, tt.ifModSince)
+			}
+			ctx := app.AcquireCtx(fctx)
+			defer app.ReleaseCtx(ctx)
+			if got := notModified(ctx, etag, tt.lastModified); got != tt.want {
+				t.Errorf("notModified = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddlewareAnswersNotModified(t *testing.T) {
+	h := &counted{}
+	app, _ := testApp(NewMemory(0, 0, 0), Policy{TTL: time.Minute}, h)
+	first, _ := get(t, app, "/vlr/news")
+	etag := first.Header.Get(fiber.HeaderETag)
+	lastModified := first.Header.Get(fiber.HeaderLastModified)
+
+	tests := []struct {
+		header, value string
+	}{
+		{fiber.HeaderIfNoneMatch, etag},
+		{fiber.HeaderIfNoneMatch, "W/" + etag},
+		{fiber.HeaderIfModifiedSince, lastModified},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/vlr/news", nil)
+		req.Header.Set(tt.header, tt.value)
+		resp, body := do(t, app, req)
+		if resp.StatusCode != http.StatusNotModified {
+			t.Errorf("%s: %s: status %d, want 304", tt.header, tt.value, resp.StatusCode)
+		}
+		if body != "" {
+			t.Errorf("%s: 304 with body %q", tt.header, body)
+		}
+		if resp.Header.Get(fiber.HeaderETag) != etag || resp.Header.Get(fiber.HeaderCacheControl) == "" {
+			t.Errorf("%s: 304 dropped the validator or caching headers: %v", tt.header, resp.Header)
+		}
+		if resp.Header.Get(fiber.HeaderContentType) != "" {
+			t.Errorf("%s: 304 carries Content-Type %q", tt.header, resp.Header.Get(fiber.HeaderContentType))
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/vlr/news", nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, `"stale"`)
+	if resp, body := do(t, app, req); resp.StatusCode != http.StatusOK || body == "" {
+		t.Errorf("mismatched ETag: status %d body %q, want the full response", resp.StatusCode, body)
+	}
+	if n := h.calls.Load(); n != 1 {
+		t.Errorf("handler called %d times, want 1", n)
+	}
+}
@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process LRU cache bounded by total size and entry count.
+// Expired entries are never returned and are dropped by a background sweep.
+type Memory struct {
+	maxBytes   int64
+	maxEntries int
+	stop       chan struct{}
+	closeOnce  sync.Once
+
+	mu    sync.Mutex
+	lru   *list.List // front is most recently used
+	items map[string]*list.Element
+	bytes int64
+}
+
+type memoryItem struct {
+	key       string
+	entry     Entry
+	expiresAt time.Time
+}
+
+// NewMemory returns a Memory cache holding at most maxBytes and maxEntries
+// (0 means no limit) and sweeping expired entries every sweepInterval.
+func NewMemory(maxBytes int64, maxEntries int, sweepInterval time.Duration) *Memory {
+	m := &Memory{
+		maxBytes:   maxBytes,
+		maxEntries: maxEntries,
+		stop:       make(chan struct{}),
+		lru:        list.New(),
+		items:      make(map[string]*list.Element),
+	}
+	if sweepInterval > 0 {
+		go m.sweep(sweepInterval)
+	}
+	return m
+}
+
+// Get returns the entry for key and marks it recently used.
+func (m *Memory) Get(_ context.Context, key string) (Entry, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.items[key]
+	if !ok {
+		return Entry{}, false, nil
+	}
+	item := el.Value.(*memoryItem)
+	if time.Now().After(item.expiresAt) {
+		m.remove(el)
+		return Entry{}, false, nil
+	}
+	m.lru.MoveToFront(el)
+	return item.entry, true, nil
+}
+
+// Set stores e for ttl, evicting least recently used entries to stay within
+// bounds. An entry larger than the whole cache is not stored.
+func (m *Memory) Set(_ context.Context, key string, e Entry, ttl time.Duration) error {
+	if m.maxBytes > 0 && e.Size() > m.maxBytes {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.items[key]; ok {
+		m.remove(el)
+	}
+	m.items[key] = m.lru.PushFront(&memoryItem{key: key, entry: e, expiresAt: time.Now().Add(ttl)})
+	m.bytes += e.Size()
+	for (m.maxBytes > 0 && m.bytes > m.maxBytes) || (m.maxEntries > 0 && m.lru.Len() > m.maxEntries) {
+		m.remove(m.lru.Back())
+	}
+	return nil
+}
+
+// Delete removes key.
+func (m *Memory) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.items[key]; ok {
+		m.remove(el)
+	}
+	return nil
+}
+
+// Keys lists the unexpired entries starting with prefix, most recently used
+// first.
+func (m *Memory) Keys(_ context.Context, prefix string) ([]KeyInfo, error) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []KeyInfo
+	for el := m.lru.Front(); el != nil; el = el.Next() {
+		item := el.Value.(*memoryItem)
+		if strings.HasPrefix(item.key, prefix) && !now.After(item.expiresAt) {
+			keys = append(keys, item.entry.Info(item.key))
+		}
+	}
+	return keys, nil
+}
+
+// DeletePrefix removes every key starting with prefix.
+func (m *Memory) DeletePrefix(_ context.Context, prefix string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for el := m.lru.Front(); el != nil; {
+		next := el.Next()
+		if strings.HasPrefix(el.Value.(*memoryItem).key, prefix) {
+			m.remove(el)
+			n++
+		}
+		el = next
+	}
+	return n, nil
+}
+
+// Close stops the sweeper.
+func (m *Memory) Close() error {
+	m.closeOnce.Do(func() { close(m.stop) })
+	return nil
+}
+
+// remove drops el. m.mu must be held.
+func (m *Memory) remove(el *list.Element) {
+	item := m.lru.Remove(el).(*memoryItem)
+	delete(m.items, item.key)
+	m.bytes -= item.entry.Size()
+}
+
+func (m *Memory) sweep(every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case now := <-ticker.C:
+			m.mu.Lock()
+			for el := m.lru.Back(); el != nil; {
+				prev := el.Prev()
+				if now.After(el.Value.(*memoryItem).expiresAt) {
+					m.remove(el)
+				}
+				el = prev
+			}
+			m.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// body returns an entry whose Size is n.
+func body(n int) Entry {
+	return Entry{Body: make([]byte, n)}
+}
+
+// keys lists the keys in m, most recently used first.
+func keys(t *testing.T, m *Memory) []string {
+	t.Helper()
+	infos, err := m.Keys(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []string
+	for _, info := range infos {
+		out = append(out, info.Key)
+	}
+	return out
+}
+
+func TestMemoryEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(0, 3, 0)
+	for _, k := range []string{"a", "b", "c"} {
+		m.Set(ctx, k, body(1), time.Minute)
+	}
+	// Reading a makes b the least recently used
+	if _, ok, _ := m.Get(ctx, "a"); !ok {
+		t.Fatal("a missing")
+	}
+	m.Set(ctx, "d", body(1), time.Minute)
+
+	if got, want := keys(t, m), []string{"d", "a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("keys = %v, want %v", got, want)
+	}
+	if _, ok, _ := m.Get(ctx, "b"); ok {
+		t.Error("b should have been evicted")
+	}
+}
+
+func TestMemoryMaxBytes(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(10, 0, 0)
+	m.Set(ctx, "a", body(4), time.Minute)
+	m.Set(ctx, "b", body(4), time.Minute)
+	m.Set(ctx, "c", body(4), time.Minute)
+	if got, want := keys(t, m), []string{"c", "b"}; !slices.Equal(got, want) {
+		t.Errorf("keys = %v, want %v", got, want)
+	}
+
+	// Replacing an entry accounts for its new size only
+	m.Set(ctx, "b", body(6), time.Minute)
+	if got, want := keys(t, m), []string{"b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("after replace: keys = %v, want %v", got, want)
+	}
+	if m.bytes != 10 {
+		t.Errorf("bytes = %d, want 10", m.bytes)
+	}
+
+	// An entry larger than the cache is dropped without evicting anything
+	m.Set(ctx, "huge", body(11), time.Minute)
+	if got, want := keys(t, m), []string{"b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("after oversized set: keys = %v, want %v", got, want)
+	}
+}
+
+func TestMemoryExpiry(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(0, 0, 0)
+	m.Set(ctx, "old", body(3), time.Millisecond)
+	m.Set(ctx, "new", body(3), time.Minute)
+	time.Sleep(5 * time.Millisecond)
+
+	if got, want := keys(t, m), []string{"new"}; !slices.Equal(got, want) {
+		t.Errorf("keys = %v, want expired entries hidden", got)
+	}
+	if _, ok, _ := m.Get(ctx, "old"); ok {
+		t.Error("expired entry returned")
+	}
+	if m.bytes != 3 {
+		t.Errorf("bytes = %d, want the expired entry dropped on Get", m.bytes)
+	}
+}
+
+func TestMemorySweep(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(0, 0, 5*time.Millisecond)
+	defer m.Close()
+	m.Set(ctx, "old", body(3), time.Millisecond)
+	m.Set(ctx, "new", body(3), time.Minute)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		m.mu.Lock()
+		n, bytes := len(m.items), m.bytes
+		m.mu.Unlock()
+		if n == 1 && bytes == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("sweep left %d entries and %d bytes, want 1 and 3", n, bytes)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMemoryDeletePrefix(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(0, 0, 0)
+	for _, k := range []string{"GET /vlr/news", "GET /vlr/stats?region=na", "GET /vlr/stats?region=eu", "GET /vlr/rankings"} {
+		m.Set(ctx, k, body(1), time.Minute)
+	}
+	n, err := m.DeletePrefix(ctx, "GET /vlr/stats")
+	if err != nil || n != 2 {
+		t.Fatalf("DeletePrefix = %d, %v; want 2, nil", n, err)
+	}
+	if got, want := keys(t, m), []string{"GET /vlr/rankings", "GET /vlr/news"}; !slices.Equal(got, want) {
+		t.Errorf("keys = %v, want %v", got, want)
+	}
+	if m.bytes != 2 {
+		t.Errorf("bytes = %d, want 2", m.bytes)
+	}
+}
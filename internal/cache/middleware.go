@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrorFunc is called when the handler or the backend fails for the request
+// cached under key. A backend failure still serves the request, uncached.
+type ErrorFunc func(key string, err error)
+
+// Locals keys. Unexported types so clients cannot set them.
+type (
+	policyKey     struct{}
+	revalidateKey struct{}
+)
+
+// WithPolicy is a route handler that caches the route's responses under p
+// instead of the middleware default. Register it before the route's own
+// handler.
+func WithPolicy(p Policy) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		ctx.Locals(policyKey{}, p)
+		return ctx.Next()
+	}
+}
+
+// Middleware serves GET responses from c, keyed by the request URL, and
+// stores successful responses under the route's policy, or def for routes
+// without one. Entries past their TTL but within their stale window are
+// served immediately while the request is re-run in the background.
+// Successful responses, cached or not, carry an ETag and are answered with
+// 304 Not Modified when the client already has them. Lookups are counted
+// per route in stats. Authenticated requests and streamed responses bypass
+// the cache.
+func Middleware(c Cache, def Policy, stats *Stats, onError ErrorFunc) fiber.Handler {
+	var revalidating sync.Map
+	revalidate := func(app *fiber.App, key string) {
+		if _, busy := revalidating.LoadOrStore(key, struct{}{}); busy {
+			return
+		}
+		go func() {
+			defer revalidating.Delete(key)
+			var req fasthttp.Request
+			req.Header.SetMethod(fiber.MethodGet)
+			req.SetRequestURI(key)
+			var fctx fasthttp.RequestCtx
+			fctx.Init(&req, nil, nil)
+			fctx.SetUserValue(revalidateKey{}, true)
+			app.Handler()(&fctx)
+		}()
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		// Never cache authenticated (admin) responses
+		if ctx.Method() != fiber.MethodGet || ctx.Get(fiber.HeaderAuthorization) != "" {
+			return ctx.Next()
+		}
+		// Fiber's strings alias the request buffer, which is reused once the
+		// request completes; the key outlives it in revalidate
+		key := strings.Clone(ctx.OriginalURL())
+		if ctx.Locals(revalidateKey{}) == nil {
+			entry, found, err := c.Get(ctx.UserContext(), key)
+			if err != nil && onError != nil {
+				onError(key, err)
+			}
+			if found {
+				now := time.Now()
+				switch age := entry.Age(now); {
+				case age < entry.TTL:
+					stats.Record(entryRoute(ctx, entry), OutcomeHit)
+					return serveEntry(ctx, entry, OutcomeHit, now)
+				case age < entry.TTL+entry.Stale:
+					stats.Record(entryRoute(ctx, entry), OutcomeStale)
+					revalidate(ctx.App(), key)
+					return serveEntry(ctx, entry, OutcomeStale, now)
+				}
+			}
+		}
+
+		err := ctx.Next()
+		// The matched route is only known once the request has been routed
+		route := ctx.Route().Path
+		if ctx.Locals(revalidateKey{}) == nil {
+			stats.Record(route, OutcomeMiss)
+		}
+		if err != nil {
+			if onError != nil {
+				onError(key, err)
+			}
+			return err
+		}
+		// Streamed responses (SSE) have no body to cache or tag
+		resp := ctx.Response()
+		if resp.StatusCode() != fiber.StatusOK || resp.IsBodyStream() {
+			return nil
+		}
+		policy, ok := ctx.Locals(policyKey{}).(Policy)
+		if !ok {
+			policy = def
+		}
+		if policy.TTL <= 0 {
+			etag := ETag(resp.Body())
+			ctx.Set(fiber.HeaderETag, etag)
+			if notModified(ctx, etag, time.Time{}) {
+				sendNotModified(ctx)
+			}
+			return nil
+		}
+		// The response buffer is reused once the request completes
+		body := append([]byte(nil), resp.Body()...)
+		now := time.Now()
+		entry := Entry{
+			Body:         body,
+			Route:        route,
+			ContentType:  string(resp.Header.ContentType()),
+			ETag:         ETag(body),
+			LastModified: now,
+			StoredAt:     now,
+			TTL:          policy.TTL,
+			Stale:        policy.Stale,
+		}
+		if err := c.Set(ctx.UserContext(), key, entry, policy.TTL+policy.Stale); err != nil && onError != nil {
+			onError(key, err)
+		}
+		setHeaders(ctx, entry, OutcomeMiss)
+		if notModified(ctx, entry.ETag, entry.LastModified) {
+			sendNotModified(ctx)
+		}
+		return nil
+	}
+}
+
+// entryRoute is the route entry was stored for, falling back to the request
+// path for entries stored without one.
+func entryRoute(ctx *fiber.Ctx, entry Entry) string {
+	if entry.Route != "" {
+		return entry.Route
+	}
+	return strings.Clone(ctx.Path())
+}
+
+func serveEntry(ctx *fiber.Ctx, entry Entry, status string, now time.Time) error {
+	setHeaders(ctx, entry, status)
+	ctx.Set(fiber.HeaderAge, strconv.FormatInt(int64(entry.Age(now)/time.Second), 10))
+	if notModified(ctx, entry.ETag, entry.LastModified) {
+		sendNotModified(ctx)
+		return nil
+	}
+	ctx.Response().Header.SetContentType(entry.ContentType)
+	return ctx.Send(entry.Body)
+}
+
+func setHeaders(ctx *fiber.Ctx, entry Entry, status string) {
+	ctx.Set("X-Cache", status)
+	ctx.Set(fiber.HeaderCacheControl, Policy{TTL: entry.TTL, Stale: entry.Stale}.CacheControl())
+	ctx.Set(fiber.HeaderETag, entry.ETag)
+	if !entry.LastModified.IsZero() {
+		ctx.Set(fiber.HeaderLastModified, entry.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,237 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// counted is a stub route handler that counts its calls and answers with
+// the call number, so a cached body is told apart from a fresh one.
+type counted struct {
+	calls atomic.Int64
+	// gate, when set, blocks every call after the first until it is closed
+	gate chan struct{}
+}
+
+func (h *counted) handle(c *fiber.Ctx) error {
+	n := h.calls.Add(1)
+	if h.gate != nil && n > 1 {
+		<-h.gate
+	}
+	return c.JSON(fiber.Map{"call": n})
+}
+
+// testApp serves /vlr/news through Middleware with policy, backed by m.
+func testApp(m Cache, policy Policy, h *counted) (*fiber.App, *Stats) {
+	stats := NewStats()
+	app := fiber.New()
+	app.Use(Middleware(m, Policy{}, stats, nil))
+	app.Get("/vlr/news", WithPolicy(policy), h.handle)
+	app.Post("/vlr/news", WithPolicy(policy), h.handle)
+	return app, stats
+}
+
+// do sends req to app and returns the response and its body.
+func do(t *testing.T, app *fiber.App, req *http.Request) (*http.Response, string) {
+	t.Helper()
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func get(t *testing.T, app *fiber.App, target string) (*http.Response, string) {
+	t.Helper()
+	return do(t, app, httptest.NewRequest(http.MethodGet, target, nil))
+}
+
+func TestMiddlewareFreshHit(t *testing.T) {
+	h := &counted{}
+	policy := Policy{TTL: time.Minute, Stale: 30 * time.Second}
+	app, stats := testApp(NewMemory(0, 0, 0), policy, h)
+
+	miss, first := get(t, app, "/vlr/news")
+	hit, second := get(t, app, "/vlr/news")
+
+	if n := h.calls.Load(); n != 1 {
+		t.Fatalf("handler called %d times, want 1", n)
+	}
+	if second != first {
+		t.Errorf("cached body %q, want %q", second, first)
+	}
+	for _, tt := range []struct {
+		resp   *http.Response
+		xcache string
+	}{{miss, OutcomeMiss}, {hit, OutcomeHit}} {
+		if got := tt.resp.Header.Get("X-Cache"); got != tt.xcache {
+			t.Errorf("X-Cache = %q, want %q", got, tt.xcache)
+		}
+		if got, want := tt.resp.Header.Get(fiber.HeaderCacheControl), "public, max-age=60, stale-while-revalidate=30"; got != want {
+			t.Errorf("%s: Cache-Control = %q, want %q", tt.xcache, got, want)
+		}
+		if tt.resp.Header.Get(fiber.HeaderETag) == "" {
+			t.Errorf("%s: no ETag", tt.xcache)
+		}
+	}
+	if got := hit.Header.Get(fiber.HeaderAge); got != "0" {
+		t.Errorf("hit Age = %q, want \"0\"", got)
+	}
+	if miss.Header.Get(fiber.HeaderAge) != "" {
+		t.Error("a miss should not carry Age")
+	}
+	routes := stats.Routes()
+	if len(routes) != 1 || routes[0].Hits != 1 || routes[0].Misses != 1 {
+		t.Errorf("stats = %+v, want one hit and one miss on /vlr/news", routes)
+	}
+}
+
+func TestMiddlewareStaleRevalidatesOnce(t *testing.T) {
+	h := &counted{gate: make(chan struct{})}
+	m := NewMemory(0, 0, 0)
+	app, _ := testApp(m, Policy{TTL: 20 * time.Millisecond, Stale: time.Minute}, h)
+
+	_, first := get(t, app, "/vlr/news")
+	time.Sleep(30 * time.Millisecond)
+
+	// Both requests are served the stale body while one background
+	// revalidation is held at the gate
+	for range 2 {
+		resp, body := get(t, app, "/vlr/news")
+		if got := resp.Header.Get("X-Cache"); got != OutcomeStale {
+			t.Errorf("X-Cache = %q, want STALE", got)
+		}
+		if body != first {
+			t.Errorf("stale body %q, want %q", body, first)
+		}
+	}
+	waitCalls(t, h, 2)
+	close(h.gate)
+
+	// The revalidated response replaces the stale entry
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		e, ok, _ := m.Get(context.Background(), "/vlr/news")
+		if ok && string(e.Body) != first {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("revalidation did not store a fresh entry")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// A second revalidation would have been dispatched before the gate
+	// opened; give it time to reach the handler
+	time.Sleep(20 * time.Millisecond)
+	if n := h.calls.Load(); n != 2 {
+		t.Errorf("handler called %d times, want the first request and one revalidation", n)
+	}
+}
+
+func TestMiddlewareSkipsAuthorizedAndNonGet(t *testing.T) {
+	tests := []struct {
+		name string
+		req  func() *http.Request
+	}{
+		{"authorization header", func() *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/vlr/news", nil)
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer admin")
+			return req
+		}},
+		{"POST", func() *http.Request {
+			return httptest.NewRequest(http.MethodPost, "/vlr/news", nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &counted{}
+			m := NewMemory(0, 0, 0)
+			app, _ := testApp(m, Policy{TTL: time.Minute}, h)
+			for range 2 {
+				resp, _ := do(t, app, tt.req())
+				if got := resp.Header.Get("X-Cache"); got != "" {
+					t.Errorf("X-Cache = %q, want the cache bypassed", got)
+				}
+			}
+			if n := h.calls.Load(); n != 2 {
+				t.Errorf("handler called %d times, want 2", n)
+			}
+			if keys, _ := m.Keys(context.Background(), ""); len(keys) != 0 {
+				t.Errorf("cached %v, want nothing", keys)
+			}
+		})
+	}
+}
+
+func TestMiddlewareNeverBuffersStreams(t *testing.T) {
+	var calls atomic.Int64
+	m := NewMemory(0, 0, 0)
+	app := fiber.New()
+	app.Use(Middleware(m, Policy{TTL: time.Minute}, NewStats(), nil))
+	app.Get("/vlr/live/stream", func(c *fiber.Ctx) error {
+		calls.Add(1)
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			io.WriteString(w, "event: ping\ndata: {}\n\n")
+			w.Flush()
+		})
+		return nil
+	})
+
+	for range 2 {
+		resp, body := get(t, app, "/vlr/live/stream")
+		if body != "event: ping\ndata: {}\n\n" {
+			t.Errorf("body = %q", body)
+		}
+		if resp.Header.Get(fiber.HeaderETag) != "" || resp.Header.Get("X-Cache") != "" {
+			t.Errorf("stream carries cache headers: %v", resp.Header)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("handler called %d times, want 2", n)
+	}
+	if keys, _ := m.Keys(context.Background(), ""); len(keys) != 0 {
+		t.Errorf("cached %v, want nothing", keys)
+	}
+}
+
+func TestMiddlewareZeroTTLNotStored(t *testing.T) {
+	h := &counted{}
+	m := NewMemory(0, 0, 0)
+	app, _ := testApp(m, Policy{}, h)
+	get(t, app, "/vlr/news")
+	resp, _ := get(t, app, "/vlr/news")
+	if n := h.calls.Load(); n != 2 {
+		t.Errorf("handler called %d times, want 2", n)
+	}
+	if resp.Header.Get(fiber.HeaderETag) == "" {
+		t.Error("uncached responses should still carry an ETag")
+	}
+	if keys, _ := m.Keys(context.Background(), ""); len(keys) != 0 {
+		t.Errorf("cached %v, want nothing", keys)
+	}
+}
+
+func waitCalls(t *testing.T, h *counted, n int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for h.calls.Load() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("handler called %d times, want %d", h.calls.Load(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis is a cache backend shared by every replica pointing at the same
+// Redis server. Expiry is left to Redis.
+type Redis struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedis connects to the Redis server at rawURL (e.g.
+// "redis://:password@host:6379/0"). Keys are stored under prefix.
+func NewRedis(rawURL, prefix string) (*Redis, error) {
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Redis{client: redis.NewClient(opts), prefix: prefix}, nil
+}
+
+// Get returns the entry for key.
+func (r *Redis) Get(ctx context.Context, key string) (Entry, bool, error) {
+	data, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return Entry{}, false, err
+	}
+	return e, true, nil
+}
+
+// Set stores e for ttl.
+func (r *Redis) Set(ctx context.Context, key string, e Entry, ttl time.Duration) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.prefix+key, data, ttl).Err()
+}
+
+// Delete removes key.
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, r.prefix+key).Err()
+}
+
+// scanBatch is how many keys SCAN is asked for per round trip.
+const scanBatch = 100
+
+// Keys lists the entries starting with prefix. It scans the keyspace and
+// reads every matching entry, so it is meant for occasional admin use.
+func (r *Redis) Keys(ctx context.Context, prefix string) ([]KeyInfo, error) {
+	var keys []KeyInfo
+	err := r.scan(ctx, prefix, func(batch []string) error {
+		values, err := r.client.MGet(ctx, batch...).Result()
+		if err != nil {
+			return err
+		}
+		for i, v := range values {
+			data, ok := v.(string)
+			if !ok {
+				// Expired since the scan
+				continue
+			}
+			var e Entry
+			if err := json.Unmarshal([]byte(data), &e); err != nil {
+				continue
+			}
+			keys = append(keys, e.Info(strings.TrimPrefix(batch[i], r.prefix)))
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// DeletePrefix removes every key starting with prefix.
+func (r *Redis) DeletePrefix(ctx context.Context, prefix string) (int, error) {
+	n := 0
+	err := r.scan(ctx, prefix, func(batch []string) error {
+		deleted, err := r.client.Del(ctx, batch...).Result()
+		n += int(deleted)
+		return err
+	})
+	return n, err
+}
+
+// scan calls fn with batches of the full Redis keys starting with prefix.
+func (r *Redis) scan(ctx context.Context, prefix string, fn func(batch []string) error) error {
+	iter := r.client.Scan(ctx, 0, r.scanPattern(prefix), scanBatch).Iterator()
+	batch := make([]string, 0, scanBatch)
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == scanBatch {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+	return nil
+}
+
+// scanPattern is the SCAN MATCH pattern for the full keys starting with
+// prefix.
+func (r *Redis) scanPattern(prefix string) string {
+	return globEscape(r.prefix+prefix) + "*"
+}
+
+// globEscape quotes the characters SCAN MATCH treats as a pattern; cache keys
+// are URLs and routinely contain "?".
+func globEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Shared reports true: every replica using the same server sees the same
+// entries.
+func (r *Redis) Shared() bool {
+	return true
+}
+
+// Close closes the connection pool.
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,44 @@
+package cache
+
+import "testing"
+
+func TestGlobEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"GET /vlr/news", "GET /vlr/news"},
+		{"GET /vlr/stats?region=na&timespan=30", `GET /vlr/stats\?region=na&timespan=30`},
+		{"a*b", `a\*b`},
+		{"[ab]", `\[ab\]`},
+		{`back\slash`, `back\\slash`},
+		{"^caret-and-dash", "^caret-and-dash"},
+		{"ünïcode?", `ünïcode\?`},
+	}
+	for _, tt := range tests {
+		if got := globEscape(tt.in); got != tt.want {
+			t.Errorf("globEscape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestScanPattern(t *testing.T) {
+	tests := []struct {
+		keyPrefix string
+		prefix    string
+		want      string
+	}{
+		{"vlrggapi:cache:", "", "vlrggapi:cache:*"},
+		{"vlrggapi:cache:", "GET /vlr/news", "vlrggapi:cache:GET /vlr/news*"},
+		// A purge of one query must not widen into a wildcard
+		{"vlrggapi:cache:", "GET /vlr/stats?region=na", `vlrggapi:cache:GET /vlr/stats\?region=na*`},
+		{"team[1]:", "GET /vlr/match*", `team\[1\]:GET /vlr/match\**`},
+		{"", "", "*"},
+	}
+	for _, tt := range tests {
+		r := &Redis{prefix: tt.keyPrefix}
+		if got := r.scanPattern(tt.prefix); got != tt.want {
+			t.Errorf("scanPattern(%q) with key prefix %q = %q, want %q", tt.prefix, tt.keyPrefix, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+)
+
+// Outcomes of a cache lookup, as reported in X-Cache.
+const (
+	OutcomeHit   = "HIT"
+	OutcomeStale = "STALE"
+	OutcomeMiss  = "MISS"
+)
+
+// RouteStats counts cache lookups for one route pattern.
+type RouteStats struct {
+	Route  string
+	Hits   uint64
+	Stale  uint64
+	Misses uint64
+}
+
+// HitRatio is the share of lookups answered from the cache, stale or not.
+func (s RouteStats) HitRatio() float64 {
+	total := s.Hits + s.Stale + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits+s.Stale) / float64(total)
+}
+
+// Stats counts cache lookups per route. It is safe for concurrent use.
+type Stats struct {
+	mu     sync.Mutex
+	routes map[string]*RouteStats
+}
+
+// NewStats returns empty Stats.
+func NewStats() *Stats {
+	return &Stats{routes: make(map[string]*RouteStats)}
+}
+
+var defaultStats = NewStats()
+
+// DefaultStats returns the process-wide lookup counters.
+func DefaultStats() *Stats {
+	return defaultStats
+}
+
+// Record counts one lookup on route with the given outcome.
+func (s *Stats) Record(route, outcome string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rs, ok := s.routes[route]
+	if !ok {
+		rs = &RouteStats{Route: route}
+		s.routes[route] = rs
+	}
+	switch outcome {
+	case OutcomeHit:
+		rs.Hits++
+	case OutcomeStale:
+		rs.Stale++
+	case OutcomeMiss:
+		rs.Misses++
+	}
+}
+
+// Routes returns the counters of every route seen, sorted by route.
+func (s *Stats) Routes() []RouteStats {
+	s.mu.Lock()
+	routes := make([]RouteStats, 0, len(s.routes))
+	for _, rs := range s.routes {
+		routes = append(routes, *rs)
+	}
+	s.mu.Unlock()
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Route < routes[j].Route })
+	return routes
+}
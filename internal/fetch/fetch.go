@@ -0,0 +1,498 @@
+// Package fetch provides the shared HTTP client used by every scraper to talk
+// to vlr.gg. It owns timeouts, connection pooling, response decompression,
+// retries with exponential backoff and a per-host concurrency limit so that a
+// slow upstream can never pin a Fiber worker indefinitely. Concurrent requests
+// for the same URL are coalesced into a single upstream fetch, and pages that
+// carry validators are revalidated with conditional requests.
+package fetch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"golang.org/x/sync/singleflight"
+
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/utils"
+)
+
+// validatorTTL is how long a body is kept for conditional requests.
+const validatorTTL = 24 * time.Hour
+
+// Config controls the behaviour of a Client.
+type Config struct {
+	// Timeout bounds a single attempt, including reading the body.
+	Timeout time.Duration
+	// MaxAttempts is the total number of attempts per request (1 = no retries).
+	MaxAttempts int
+	// RetryDelay is the base delay between attempts; it doubles every retry.
+	RetryDelay time.Duration
+	// MaxPerHost caps the number of in-flight requests to a single host.
+	MaxPerHost int
+	// MaxIdleConnsPerHost sizes the keep-alive pool for each host.
+	MaxIdleConnsPerHost int
+	// MaxBodyBytes caps how much of a response body is read into memory.
+	MaxBodyBytes int64
+	// ValidatorBytes caps the total size of the bodies kept to answer
+	// upstream 304 Not Modified responses.
+	ValidatorBytes int64
+}
+
+// DefaultConfig returns the configuration used when nothing is overridden.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:             15 * time.Second,
+		MaxAttempts:         3,
+		RetryDelay:          500 * time.Millisecond,
+		MaxPerHost:          8,
+		MaxIdleConnsPerHost: 16,
+		MaxBodyBytes:        10 << 20,
+		ValidatorBytes:      32 << 20,
+	}
+}
+
+// ConfigFromEnv returns DefaultConfig with any FETCH_* environment overrides
+// applied. Invalid values are ignored.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	if d, err := time.ParseDuration(os.Getenv("FETCH_TIMEOUT")); err == nil && d > 0 {
+		cfg.Timeout = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("FETCH_MAX_ATTEMPTS")); err == nil && n > 0 {
+		cfg.MaxAttempts = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("FETCH_RETRY_DELAY")); err == nil && d >= 0 {
+		cfg.RetryDelay = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("FETCH_MAX_PER_HOST")); err == nil && n > 0 {
+		cfg.MaxPerHost = n
+	}
+	return cfg
+}
+
+// Request describes a single upstream GET. Zero-valued fields fall back to
+// the client's Config.
+type Request struct {
+	URL         string
+	MaxAttempts int
+	RetryDelay  time.Duration
+	Timeout     time.Duration
+}
+
+// Response is a fully read upstream response with its body decompressed.
+type Response struct {
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// ErrBodyTooLarge is returned when a response body exceeds
+// Config.MaxBodyBytes. The body is not returned, since parsing truncated HTML
+// would silently drop data.
+var ErrBodyTooLarge = errors.New("fetch: response body too large")
+
+// StatusError is returned when upstream keeps answering with a retryable
+// status code (429 or 5xx) after all attempts are exhausted.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("fetch %s: upstream returned status %d", e.URL, e.StatusCode)
+}
+
+// Stats counts how many requests were coalesced into shared upstream
+// fetches.
+type Stats struct {
+	// Requests is the number of calls to Do.
+	Requests uint64
+	// Fetches is the number of upstream fetches those calls caused.
+	Fetches uint64
+	// Deduplicated is the number of calls that joined a fetch already in
+	// flight for the same URL instead of starting their own.
+	Deduplicated uint64
+	// InFlight is the number of upstream fetches currently running.
+	InFlight int64
+	// NotModified is the number of fetches upstream answered with 304 Not
+	// Modified, served from the body kept from an earlier fetch.
+	NotModified uint64
+}
+
+// Client is a retrying, host-limited HTTP client. It is safe for concurrent use.
+type Client struct {
+	cfg  Config
+	http *http.Client
+
+	mu    sync.Mutex
+	hosts map[string]chan struct{}
+
+	flights     singleflight.Group
+	requests    atomic.Uint64
+	fetches     atomic.Uint64
+	inFlight    atomic.Int64
+	notModified atomic.Uint64
+
+	// validators holds the last body of each URL that came with an ETag or
+	// Last-Modified, keyed by flightKey.
+	validators *cache.Memory
+}
+
+// New builds a Client from cfg.
+func New(cfg Config) *Client {
+	def := DefaultConfig()
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = def.Timeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = def.MaxAttempts
+	}
+	if cfg.MaxPerHost <= 0 {
+		cfg.MaxPerHost = def.MaxPerHost
+	}
+	if cfg.MaxIdleConnsPerHost <= 0 {
+		cfg.MaxIdleConnsPerHost = def.MaxIdleConnsPerHost
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = def.MaxBodyBytes
+	}
+	if cfg.ValidatorBytes <= 0 {
+		cfg.ValidatorBytes = def.ValidatorBytes
+	}
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: cfg.Timeout,
+		// Accept-Encoding is set explicitly so brotli can be negotiated;
+		// decoding is done in decodeBody.
+		DisableCompression: true,
+	}
+	return &Client{
+		cfg:   cfg,
+		http:  &http.Client{Transport: transport},
+		hosts: make(map[string]chan struct{}),
+		// No sweeper: the size bound keeps it small and expired entries
+		// are dropped on lookup
+		validators: cache.NewMemory(cfg.ValidatorBytes, 0, 0),
+	}
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultClient = New(DefaultConfig())
+)
+
+// Default returns the process-wide client used by the scrapers.
+func Default() *Client {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultClient
+}
+
+// SetDefault replaces the process-wide client. It is intended to be called
+// once during startup.
+func SetDefault(c *Client) {
+	defaultMu.Lock()
+	defaultClient = c
+	defaultMu.Unlock()
+}
+
+// Get fetches rawURL with the default client.
+func Get(ctx context.Context, rawURL string) (*Response, error) {
+	return Default().Do(ctx, Request{URL: rawURL})
+}
+
+// Do fetches rawURL with the default client using per-request overrides.
+func Do(ctx context.Context, req Request) (*Response, error) {
+	return Default().Do(ctx, req)
+}
+
+// Get fetches rawURL using the client's defaults.
+func (c *Client) Get(ctx context.Context, rawURL string) (*Response, error) {
+	return c.Do(ctx, Request{URL: rawURL})
+}
+
+// Stats returns the client's request coalescing counters.
+func (c *Client) Stats() Stats {
+	requests := c.requests.Load()
+	fetches := c.fetches.Load()
+	s := Stats{Requests: requests, Fetches: fetches, InFlight: c.inFlight.Load(), NotModified: c.notModified.Load()}
+	if requests > fetches {
+		s.Deduplicated = requests - fetches
+	}
+	return s
+}
+
+// Do performs req, retrying transport failures and 429/5xx responses with
+// exponential backoff. Non-retryable statuses (e.g. 404) are returned as a
+// Response without an error so callers can decide how to surface them.
+//
+// Concurrent calls for the same normalized URL share one upstream fetch,
+// made with the options of the first caller, and receive the same Response,
+// which must therefore not be modified.
+func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
+	c.requests.Add(1)
+	ch := c.flights.DoChan(flightKey(req.URL), func() (any, error) {
+		c.fetches.Add(1)
+		c.inFlight.Add(1)
+		defer c.inFlight.Add(-1)
+		// Detached so that callers who joined the flight are not failed
+		// when the first caller gives up
+		return c.do(context.WithoutCancel(ctx), req)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*Response), nil
+	}
+}
+
+func (c *Client) do(ctx context.Context, req Request) (*Response, error) {
+	attempts := req.MaxAttempts
+	if attempts <= 0 {
+		attempts = c.cfg.MaxAttempts
+	}
+	delay := req.RetryDelay
+	if delay <= 0 {
+		delay = c.cfg.RetryDelay
+	}
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = c.cfg.Timeout
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		resp, retryAfter, err := c.attempt(ctx, req.URL, timeout)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil || !retryable(err) || attempt == attempts {
+			break
+		}
+		wait := backoff(delay, attempt)
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	return nil, lastErr
+}
+
+func (c *Client) attempt(ctx context.Context, rawURL string, timeout time.Duration) (*Response, time.Duration, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, 0, err
+	}
+	release, err := c.acquire(ctx, u.Host)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	for k, v := range utils.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Accept-Encoding", "gzip, br")
+	key := flightKey(rawURL)
+	prev, hasPrev, _ := c.validators.Get(ctx, key)
+	if hasPrev {
+		if prev.ETag != "" {
+			httpReq.Header.Set("If-None-Match", prev.ETag)
+		}
+		if !prev.LastModified.IsZero() {
+			httpReq.Header.Set("If-Modified-Since", prev.LastModified.UTC().Format(http.TimeFormat))
+		}
+	}
+
+	httpResp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusTooManyRequests || httpResp.StatusCode >= 500 {
+		io.Copy(io.Discard, io.LimitReader(httpResp.Body, 64<<10))
+		return nil, retryAfter(httpResp.Header), &StatusError{URL: rawURL, StatusCode: httpResp.StatusCode}
+	}
+
+	if httpResp.StatusCode == http.StatusNotModified && hasPrev {
+		c.notModified.Add(1)
+		return &Response{
+			URL:        rawURL,
+			StatusCode: http.StatusOK,
+			Header:     httpResp.Header,
+			Body:       prev.Body,
+		}, 0, nil
+	}
+
+	body, err := decodeBody(httpResp, c.cfg.MaxBodyBytes)
+	if err != nil {
+		return nil, 0, err
+	}
+	c.remember(key, httpResp, body)
+	return &Response{
+		URL:        rawURL,
+		StatusCode: httpResp.StatusCode,
+		Header:     httpResp.Header,
+		Body:       body,
+	}, 0, nil
+}
+
+// remember keeps body for conditional requests if the response came with
+// validators, and forgets any earlier body otherwise.
+func (c *Client) remember(key string, resp *http.Response, body []byte) {
+	ctx := context.Background()
+	etag := resp.Header.Get("ETag")
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified.IsZero()) {
+		c.validators.Delete(ctx, key)
+		return
+	}
+	c.validators.Set(ctx, key, cache.Entry{
+		Body:         body,
+		ETag:         etag,
+		LastModified: lastModified,
+		StoredAt:     time.Now(),
+	}, validatorTTL)
+}
+
+// flightKey normalizes rawURL so that equivalent URLs share a flight: scheme
+// and host are lower-cased, default ports and the fragment are dropped and
+// query parameters are sorted.
+func flightKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		u.Host = strings.TrimSuffix(u.Host, ":"+port)
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawQuery = u.Query().Encode()
+	u.Fragment, u.RawFragment = "", ""
+	return u.String()
+}
+
+// acquire blocks until a slot for host is available or ctx is done.
+func (c *Client) acquire(ctx context.Context, host string) (func(), error) {
+	c.mu.Lock()
+	sem, ok := c.hosts[host]
+	if !ok {
+		sem = make(chan struct{}, c.cfg.MaxPerHost)
+		c.hosts[host] = sem
+	}
+	c.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func decodeBody(resp *http.Response, limit int64) ([]byte, error) {
+	var r io.Reader = resp.Body
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	case "br":
+		r = brotli.NewReader(resp.Body)
+	}
+	// One byte past the limit tells a body that is exactly limit bytes from
+	// one that was cut off
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(r, limit+1)); err != nil {
+		return nil, err
+	}
+	if int64(buf.Len()) > limit {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limit)
+	}
+	return buf.Bytes(), nil
+}
+
+func retryable(err error) bool {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return true
+	}
+	// *url.Error implements net.Error itself, so judge what it wraps:
+	// malformed URLs and unsupported schemes fail the same way every time
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		err = ue.Err
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	// Timeouts, DNS failures and dial/read/write errors on the connection
+	var ne net.Error
+	return errors.As(err, &ne)
+}
+
+// backoff returns base * 2^(attempt-1) with up to 20% jitter.
+func backoff(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	d := base << (attempt - 1)
+	return d + time.Duration(rand.Int64N(int64(d)/5+1))
+}
+
+func retryAfter(h http.Header) time.Duration {
+	secs, err := strconv.Atoi(strings.TrimSpace(h.Get("Retry-After")))
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,465 @@
+package fetch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+// upstream starts a server that answers the nth request (from 0) with
+// handle(n, w, r) and records when each request arrived.
+func upstream(t *testing.T, handle func(n int, w http.ResponseWriter, r *http.Request)) (*httptest.Server, func() []time.Time) {
+	t.Helper()
+	var mu sync.Mutex
+	var hits []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		n := len(hits)
+		hits = append(hits, time.Now())
+		mu.Unlock()
+		handle(n, w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]time.Time(nil), hits...)
+	}
+}
+
+// statuses answers the nth request with the nth status, repeating the last
+// one, and "ok" as the body.
+func statuses(codes ...int) func(int, http.ResponseWriter, *http.Request) {
+	return func(n int, w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(codes[min(n, len(codes)-1)])
+		io.WriteString(w, "ok")
+	}
+}
+
+func TestRetriesWithBackoff(t *testing.T) {
+	const base = 40 * time.Millisecond
+	srv, hits := upstream(t, statuses(http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK))
+	c := New(Config{MaxAttempts: 3, RetryDelay: base})
+
+	resp, err := c.Get(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || string(resp.Body) != "ok" {
+		t.Fatalf("got %d %q, want 200 \"ok\"", resp.StatusCode, resp.Body)
+	}
+	got := hits()
+	if len(got) != 3 {
+		t.Fatalf("got %d attempts, want 3", len(got))
+	}
+	// The delay doubles after every failed attempt
+	for i, want := range []time.Duration{base, 2 * base} {
+		if gap := got[i+1].Sub(got[i]); gap < want {
+			t.Errorf("retry %d after %v, want at least %v", i+1, gap, want)
+		}
+	}
+}
+
+func TestStatusHandling(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		attempts int
+		wantErr  bool
+	}{
+		{"429 is retried", http.StatusTooManyRequests, 3, true},
+		{"5xx is retried", http.StatusBadGateway, 3, true},
+		{"404 is returned", http.StatusNotFound, 1, false},
+		{"403 is returned", http.StatusForbidden, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hits := upstream(t, statuses(tt.status))
+			c := New(Config{MaxAttempts: 3, RetryDelay: time.Millisecond})
+
+			resp, err := c.Get(context.Background(), srv.URL)
+			if n := len(hits()); n != tt.attempts {
+				t.Errorf("got %d attempts, want %d", n, tt.attempts)
+			}
+			if !tt.wantErr {
+				if err != nil || resp.StatusCode != tt.status {
+					t.Fatalf("got %v, %v; want a %d response", resp, err, tt.status)
+				}
+				return
+			}
+			var se *StatusError
+			if !errors.As(err, &se) || se.StatusCode != tt.status {
+				t.Fatalf("got error %v, want a StatusError with %d", err, tt.status)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	srv, hits := upstream(t, func(n int, w http.ResponseWriter, _ *http.Request) {
+		if n == 0 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, "ok")
+	})
+	c := New(Config{MaxAttempts: 2, RetryDelay: time.Millisecond})
+
+	if _, err := c.Get(context.Background(), srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	got := hits()
+	if len(got) != 2 {
+		t.Fatalf("got %d attempts, want 2", len(got))
+	}
+	if gap := got[1].Sub(got[0]); gap < time.Second {
+		t.Errorf("retried after %v, want Retry-After's 1s over the 1ms backoff", gap)
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{" 2 ", 2 * time.Second},
+		{"0", 0},
+		{"-1", 0},
+		{"Wed, 21 Oct 2026 07:28:00 GMT", 0},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		h.Set("Retry-After", tt.value)
+		if got := retryAfter(h); got != tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	dial := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"status", &StatusError{URL: "u", StatusCode: 503}, true},
+		{"wrapped status", fmt.Errorf("scrape: %w", &StatusError{URL: "u", StatusCode: 429}), true},
+		{"dial error", &url.Error{Op: "Get", URL: "u", Err: dial}, true},
+		{"timeout", &url.Error{Op: "Get", URL: "u", Err: context.DeadlineExceeded}, true},
+		{"unexpected EOF", &url.Error{Op: "Get", URL: "u", Err: io.ErrUnexpectedEOF}, true},
+		{"EOF", &url.Error{Op: "Get", URL: "u", Err: io.EOF}, true},
+		{"unsupported scheme", &url.Error{Op: "Get", URL: "u", Err: errors.New(`unsupported protocol scheme "ftp"`)}, false},
+		{"body too large", fmt.Errorf("%w: limit is 1 bytes", ErrBodyTooLarge), false},
+		{"plain error", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		if got := retryable(tt.err); got != tt.want {
+			t.Errorf("%s: retryable(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestPermanentRequestErrorNotRetried(t *testing.T) {
+	c := New(Config{MaxAttempts: 3, RetryDelay: time.Second})
+	start := time.Now()
+	if _, err := c.Get(context.Background(), "ftp://example.com/"); err == nil {
+		t.Fatal("expected an error for an unsupported scheme")
+	}
+	if d := time.Since(start); d >= time.Second {
+		t.Errorf("took %v, an unsupported scheme should fail without backing off", d)
+	}
+}
+
+func TestDecodesCompressedBodies(t *testing.T) {
+	const page = "<html><body>rankings</body></html>"
+	var gz, br bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	io.WriteString(zw, page)
+	zw.Close()
+	bw := brotli.NewWriter(&br)
+	io.WriteString(bw, page)
+	bw.Close()
+
+	tests := []struct {
+		encoding string
+		body     []byte
+	}{
+		{"", []byte(page)},
+		{"gzip", gz.Bytes()},
+		{"br", br.Bytes()},
+		{" GZIP ", gz.Bytes()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			var accept string
+			srv, _ := upstream(t, func(_ int, w http.ResponseWriter, r *http.Request) {
+				accept = r.Header.Get("Accept-Encoding")
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.Write(tt.body)
+			})
+			resp, err := New(Config{}).Get(context.Background(), srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(resp.Body) != page {
+				t.Errorf("body = %q, want %q", resp.Body, page)
+			}
+			if accept != "gzip, br" {
+				t.Errorf("Accept-Encoding = %q, want \"gzip, br\"", accept)
+			}
+		})
+	}
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	const limit = 16
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	io.WriteString(zw, strings.Repeat("a", 4*limit))
+	zw.Close()
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		wantErr  bool
+	}{
+		{"at the limit", "", bytes.Repeat([]byte("a"), limit), false},
+		{"one byte over", "", bytes.Repeat([]byte("a"), limit+1), true},
+		// The limit applies to the decoded body, not the bytes on the wire
+		{"decompresses past the limit", "gzip", gz.Bytes(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hits := upstream(t, func(_ int, w http.ResponseWriter, _ *http.Request) {
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.Write(tt.body)
+			})
+			resp, err := New(Config{MaxBodyBytes: limit, MaxAttempts: 3}).Get(context.Background(), srv.URL)
+			if !tt.wantErr {
+				if err != nil || len(resp.Body) != limit {
+					t.Fatalf("got %v, %v; want a %d byte body", resp, err, limit)
+				}
+				return
+			}
+			if !errors.Is(err, ErrBodyTooLarge) {
+				t.Fatalf("got error %v, want ErrBodyTooLarge", err)
+			}
+			if n := len(hits()); n != 1 {
+				t.Errorf("got %d attempts, an oversized body should not be retried", n)
+			}
+		})
+	}
+}
+
+func TestMaxPerHost(t *testing.T) {
+	const limit, callers = 2, 6
+	release := make(chan struct{})
+	var running, peak atomic.Int64
+	srv, _ := upstream(t, func(_ int, w http.ResponseWriter, _ *http.Request) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+	})
+	c := New(Config{MaxPerHost: limit})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Distinct URLs so the calls are not coalesced
+			_, err := c.Get(context.Background(), fmt.Sprintf("%s/?page=%d", srv.URL, i))
+			errs <- err
+		}()
+	}
+	waitFor(t, func() bool { return running.Load() == limit })
+	// Give any caller that slipped past the semaphore time to show up
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if p := peak.Load(); p != limit {
+		t.Errorf("peak concurrency %d, want %d", p, limit)
+	}
+}
+
+func TestAcquireHonoursContext(t *testing.T) {
+	c := New(Config{MaxPerHost: 1})
+	release, err := c.acquire(context.Background(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.acquire(ctx, "example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("acquire on a full host = %v, want the context's error", err)
+	}
+	// Other hosts have their own slots
+	other, err := c.acquire(context.Background(), "cdn.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other()
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConcurrentRequestsShareOneFetch(t *testing.T) {
+	const callers = 8
+	release := make(chan struct{})
+	srv, hits := upstream(t, func(_ int, w http.ResponseWriter, _ *http.Request) {
+		<-release
+		io.WriteString(w, "ok")
+	})
+	c := New(Config{})
+
+	var wg sync.WaitGroup
+	bodies := make(chan *Response, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Get(context.Background(), srv.URL+"/rankings")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			bodies <- resp
+		}()
+	}
+	waitFor(t, func() bool { return c.Stats().Requests == callers })
+	if s := c.Stats(); s.InFlight != 1 || s.Fetches != 1 {
+		t.Errorf("while blocked: InFlight %d, Fetches %d; want 1, 1", s.InFlight, s.Fetches)
+	}
+	close(release)
+	wg.Wait()
+	close(bodies)
+
+	var first *Response
+	for resp := range bodies {
+		if first == nil {
+			first = resp
+		}
+		if resp != first {
+			t.Error("callers received different responses from one flight")
+		}
+	}
+	if n := len(hits()); n != 1 {
+		t.Errorf("got %d upstream requests, want 1", n)
+	}
+	want := Stats{Requests: callers, Fetches: 1, Deduplicated: callers - 1}
+	if s := c.Stats(); s != want {
+		t.Errorf("Stats() = %+v, want %+v", s, want)
+	}
+}
+
+func TestCancelledCallerDoesNotAbortSharedFetch(t *testing.T) {
+	release := make(chan struct{})
+	srv, hits := upstream(t, func(_ int, w http.ResponseWriter, _ *http.Request) {
+		<-release
+		io.WriteString(w, "ok")
+	})
+	c := New(Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := c.Get(ctx, srv.URL)
+		first <- err
+	}()
+	waitFor(t, func() bool { return len(hits()) == 1 })
+
+	second := make(chan *Response, 1)
+	go func() {
+		resp, err := c.Get(context.Background(), srv.URL)
+		if err != nil {
+			t.Error(err)
+		}
+		second <- resp
+	}()
+	waitFor(t, func() bool { return c.Stats().Requests == 2 })
+
+	// The caller that started the flight gives up
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller got %v, want context.Canceled", err)
+	}
+	close(release)
+	if resp := <-second; resp == nil || string(resp.Body) != "ok" {
+		t.Fatalf("joined caller got %v, want the shared response", resp)
+	}
+	if n := len(hits()); n != 1 {
+		t.Errorf("got %d upstream requests, want 1", n)
+	}
+	if s := c.Stats(); s.Deduplicated != 1 || s.InFlight != 0 {
+		t.Errorf("Stats() = %+v, want 1 deduplicated and none in flight", s)
+	}
+}
+
+func TestFlightKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"https://www.vlr.gg/stats?b=2&a=1", "https://www.vlr.gg/stats?a=1&b=2", true},
+		{"HTTPS://WWW.VLR.GG/stats", "https://www.vlr.gg/stats", true},
+		{"https://www.vlr.gg:443/rankings", "https://www.vlr.gg/rankings", true},
+		{"http://www.vlr.gg:80", "http://www.vlr.gg/", true},
+		{"https://www.vlr.gg/news#top", "https://www.vlr.gg/news", true},
+		{"https://www.vlr.gg/stats?a=1", "https://www.vlr.gg/stats?a=2", false},
+		{"https://www.vlr.gg:8443/news", "https://www.vlr.gg/news", false},
+		{"https://www.vlr.gg/News", "https://www.vlr.gg/news", false},
+	}
+	for _, tt := range tests {
+		if same := flightKey(tt.a) == flightKey(tt.b); same != tt.same {
+			t.Errorf("flightKey(%q) == flightKey(%q) is %v, want %v", tt.a, tt.b, same, tt.same)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package live
+
+import (
+	"strconv"
+	"time"
+
+	"vlrggapi/pkg/models"
+)
+
+// Event types emitted on the live stream.
+const (
+	EventSnapshot     = "snapshot"
+	EventMatchStarted = "match_started"
+	EventRoundWon     = "round_won"
+	EventMapEnded     = "map_ended"
+	EventMatchEnded   = "match_ended"
+)
+
+// Diff compares two successive live snapshots and returns the events that
+// explain the change, in the order of next followed by any matches that
+// dropped off. Round counters that go down (a new map starting) produce no
+// round_won events, and rounds are only compared while both snapshots are
+// on the same map and carry round scores.
+func Diff(prev, next []models.LiveMatch, now time.Time) []models.LiveEvent {
+	before := make(map[string]models.LiveMatch, len(prev))
+	for _, m := range prev {
+		before[matchKey(m)] = m
+	}
+
+	var events []models.LiveEvent
+	seen := make(map[string]bool, len(next))
+	for i := range next {
+		m := &next[i]
+		key := matchKey(*m)
+		seen[key] = true
+		old, ok := before[key]
+		if !ok {
+			events = append(events, event(EventMatchStarted, m, 0, now))
+			continue
+		}
+
+		if hasRounds(old) && hasRounds(*m) && sameMap(old, *m) {
+			for team := 1; team <= 2; team++ {
+				for n := roundsWon(*m, team) - roundsWon(old, team); n > 0; n-- {
+					events = append(events, event(EventRoundWon, m, team, now))
+				}
+			}
+		}
+		if hasSeries(old) && hasSeries(*m) {
+			for team := 1; team <= 2; team++ {
+				for n := seriesScore(*m, team) - seriesScore(old, team); n > 0; n-- {
+					events = append(events, event(EventMapEnded, m, team, now))
+				}
+			}
+		}
+	}
+
+	for i := range prev {
+		m := &prev[i]
+		if seen[matchKey(*m)] {
+			continue
+		}
+		winner := 0
+		switch s1, s2 := seriesScore(*m, 1), seriesScore(*m, 2); {
+		case s1 > s2:
+			winner = 1
+		case s2 > s1:
+			winner = 2
+		}
+		events = append(events, event(EventMatchEnded, m, winner, now))
+	}
+	return events
+}
+
+func event(typ string, m *models.LiveMatch, team int, now time.Time) models.LiveEvent {
+	match := *m
+	return models.LiveEvent{
+		Type:    typ,
+		MatchID: m.MatchID,
+		Team:    team,
+		Match:   &match,
+		At:      now,
+	}
+}
+
+// matchKey identifies a match across snapshots. The match page URL is the
+// fallback for cards whose ID could not be parsed.
+func matchKey(m models.LiveMatch) string {
+	if m.MatchID != 0 {
+		return "id:" + strconv.Itoa(m.MatchID)
+	}
+	return "url:" + m.MatchPage
+}
+
+func sameMap(a, b models.LiveMatch) bool {
+	if a.MapNumber != nil && b.MapNumber != nil {
+		return *a.MapNumber == *b.MapNumber
+	}
+	return a.CurrentMap == b.CurrentMap
+}
+
+// hasRounds and hasSeries report whether the scores were scraped at all, so
+// a match page that failed to load once does not replay every round.
+func hasRounds(m models.LiveMatch) bool {
+	return m.Team1RoundCT != nil || m.Team1RoundT != nil || m.Team2RoundCT != nil || m.Team2RoundT != nil
+}
+
+func hasSeries(m models.LiveMatch) bool {
+	return m.Score1 != nil && m.Score2 != nil
+}
+
+// roundsWon is a team's round count on the current map.
+func roundsWon(m models.LiveMatch, team int) int {
+	if team == 1 {
+		return deref(m.Team1RoundCT) + deref(m.Team1RoundT)
+	}
+	return deref(m.Team2RoundCT) + deref(m.Team2RoundT)
+}
+
+// seriesScore is a team's count of maps won.
+func seriesScore(m models.LiveMatch, team int) int {
+	if team == 1 {
+		return deref(m.Score1)
+	}
+	return deref(m.Score2)
+}
+
+func deref(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
@@ -0,0 +1,168 @@
+package live
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"vlrggapi/pkg/models"
+)
+
+// state is the part of a live match Diff looks at. A negative score or
+// round count is left unscraped (nil); mapNumber 0 is unknown.
+type state struct {
+	id             int
+	score1, score2 int
+	rounds1        int // team 1's rounds, split over CT and T
+	rounds2        int
+	mapNumber      int
+	mapName        string
+}
+
+func (s state) match() models.LiveMatch {
+	ptr := func(n int) *int {
+		if n < 0 {
+			return nil
+		}
+		return &n
+	}
+	m := models.LiveMatch{
+		MatchID:    s.id,
+		MatchPage:  "https://www.vlr.gg/" + string(rune('a'+s.id)),
+		Score1:     ptr(s.score1),
+		Score2:     ptr(s.score2),
+		CurrentMap: s.mapName,
+	}
+	if s.rounds1 >= 0 {
+		m.Team1RoundCT, m.Team1RoundT = ptr(s.rounds1/2), ptr(s.rounds1-s.rounds1/2)
+	}
+	if s.rounds2 >= 0 {
+		m.Team2RoundCT, m.Team2RoundT = ptr(s.rounds2/2), ptr(s.rounds2-s.rounds2/2)
+	}
+	if s.mapNumber > 0 {
+		m.MapNumber = ptr(s.mapNumber)
+	}
+	return m
+}
+
+func matches(states ...state) []models.LiveMatch {
+	var out []models.LiveMatch
+	for _, s := range states {
+		out = append(out, s.match())
+	}
+	return out
+}
+
+// ev is the comparable part of a LiveEvent.
+type ev struct {
+	typ   string
+	match int
+	team  int
+}
+
+func TestDiff(t *testing.T) {
+	const id = 100
+	onAscent := state{id: id, score1: 0, score2: 0, rounds1: 5, rounds2: 3, mapNumber: 1, mapName: "Ascent"}
+	with := func(s state, f func(*state)) state {
+		f(&s)
+		return s
+	}
+
+	tests := []struct {
+		name       string
+		prev, next []models.LiveMatch
+		want       []ev
+	}{
+		{"no change", matches(onAscent), matches(onAscent), nil},
+		{"match started", nil, matches(onAscent), []ev{{EventMatchStarted, id, 0}}},
+		{"round won by team 1",
+			matches(onAscent),
+			matches(with(onAscent, func(s *state) { s.rounds1++ })),
+			[]ev{{EventRoundWon, id, 1}}},
+		{"two rounds between polls",
+			matches(onAscent),
+			matches(with(onAscent, func(s *state) { s.rounds2 += 2 })),
+			[]ev{{EventRoundWon, id, 2}, {EventRoundWon, id, 2}}},
+		{"both teams scored",
+			matches(onAscent),
+			matches(with(onAscent, func(s *state) { s.rounds1++; s.rounds2++ })),
+			[]ev{{EventRoundWon, id, 1}, {EventRoundWon, id, 2}}},
+		{"map ended and the next began",
+			matches(with(onAscent, func(s *state) { s.rounds1 = 12 })),
+			matches(state{id: id, score1: 1, score2: 0, rounds1: 0, rounds2: 1, mapNumber: 2, mapName: "Haven"}),
+			[]ev{{EventMapEnded, id, 1}}},
+		{"round counters going down on the same map",
+			matches(onAscent),
+			matches(with(onAscent, func(s *state) { s.rounds1 = 0 })),
+			nil},
+		{"same map name without map numbers",
+			matches(with(onAscent, func(s *state) { s.mapNumber = 0 })),
+			matches(with(onAscent, func(s *state) { s.mapNumber = 0; s.rounds1++ })),
+			[]ev{{EventRoundWon, id, 1}}},
+		// Without map numbers a new map name means the rounds restarted
+		{"map name changed without map numbers",
+			matches(with(onAscent, func(s *state) { s.mapNumber = 0 })),
+			matches(with(onAscent, func(s *state) { s.mapNumber = 0; s.mapName = "Haven"; s.rounds1 = 9 })),
+			nil},
+		{"map number wins over a changed name",
+			matches(onAscent),
+			matches(with(onAscent, func(s *state) { s.mapName = "Unknown"; s.rounds1++ })),
+			[]ev{{EventRoundWon, id, 1}}},
+		{"rounds missing from the previous poll",
+			matches(with(onAscent, func(s *state) { s.rounds1, s.rounds2 = -1, -1 })),
+			matches(onAscent),
+			nil},
+		{"series score missing from the next poll",
+			matches(onAscent),
+			matches(with(onAscent, func(s *state) { s.score1, s.score2 = -1, -1 })),
+			nil},
+		{"match disappeared, team 2 ahead",
+			matches(with(onAscent, func(s *state) { s.score2 = 2 })),
+			nil,
+			[]ev{{EventMatchEnded, id, 2}}},
+		{"match disappeared level",
+			matches(with(onAscent, func(s *state) { s.score1, s.score2 = 1, 1 })),
+			nil,
+			[]ev{{EventMatchEnded, id, 0}}},
+		{"next order, then dropped matches",
+			matches(state{id: 1, rounds1: 0, rounds2: 0}, state{id: 2, rounds1: 0, rounds2: 0}),
+			matches(state{id: 3}, state{id: 2, rounds1: 1, rounds2: 0}),
+			[]ev{{EventMatchStarted, 3, 0}, {EventRoundWon, 2, 1}, {EventMatchEnded, 1, 0}}},
+	}
+	now := time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []ev
+			for _, e := range Diff(tt.prev, tt.next, now) {
+				got = append(got, ev{e.Type, e.MatchID, e.Team})
+				if e.Match == nil || !e.At.Equal(now) {
+					t.Errorf("%s event without its match or time: %+v", e.Type, e)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Diff = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffKeysMatchesWithoutIDByPage(t *testing.T) {
+	a := models.LiveMatch{MatchPage: "https://www.vlr.gg/1/a"}
+	b := models.LiveMatch{MatchPage: "https://www.vlr.gg/2/b"}
+	got := Diff([]models.LiveMatch{a}, []models.LiveMatch{b}, time.Now())
+	if len(got) != 2 || got[0].Type != EventMatchStarted || got[1].Type != EventMatchEnded {
+		t.Errorf("Diff = %+v, want b started and a ended", got)
+	}
+	if got := Diff([]models.LiveMatch{a}, []models.LiveMatch{a}, time.Now()); len(got) != 0 {
+		t.Errorf("Diff of an unchanged match without ID = %+v, want nothing", got)
+	}
+}
+
+func TestEventCopiesMatch(t *testing.T) {
+	next := matches(state{id: 1})
+	events := Diff(nil, next, time.Now())
+	next[0].Team1 = "changed"
+	if events[0].Match.Team1 == "changed" {
+		t.Error("event shares its match with the snapshot")
+	}
+}
@@ -0,0 +1,69 @@
+package live
+
+import (
+	"slices"
+
+	"vlrggapi/pkg/models"
+)
+
+// Filter selects live matches by match, event or team ID. A match passes if
+// any of its IDs is in the filter; an empty filter passes nothing.
+type Filter struct {
+	MatchIDs []int
+	EventIDs []int
+	TeamIDs  []int
+}
+
+// Add includes the non-zero IDs.
+func (f *Filter) Add(matchID, eventID, teamID int) {
+	f.MatchIDs = addID(f.MatchIDs, matchID)
+	f.EventIDs = addID(f.EventIDs, eventID)
+	f.TeamIDs = addID(f.TeamIDs, teamID)
+}
+
+// Remove drops the non-zero IDs.
+func (f *Filter) Remove(matchID, eventID, teamID int) {
+	f.MatchIDs = slices.DeleteFunc(f.MatchIDs, func(id int) bool { return id == matchID })
+	f.EventIDs = slices.DeleteFunc(f.EventIDs, func(id int) bool { return id == eventID })
+	f.TeamIDs = slices.DeleteFunc(f.TeamIDs, func(id int) bool { return id == teamID })
+}
+
+// Match reports whether m passes the filter.
+func (f *Filter) Match(m *models.LiveMatch) bool {
+	if m == nil {
+		return false
+	}
+	return slices.Contains(f.MatchIDs, m.MatchID) ||
+		slices.Contains(f.EventIDs, m.EventID) ||
+		slices.Contains(f.TeamIDs, m.Team1ID) ||
+		slices.Contains(f.TeamIDs, m.Team2ID)
+}
+
+// Matches returns the matches that pass the filter.
+func (f *Filter) Matches(matches []models.LiveMatch) []models.LiveMatch {
+	var out []models.LiveMatch
+	for i := range matches {
+		if f.Match(&matches[i]) {
+			out = append(out, matches[i])
+		}
+	}
+	return out
+}
+
+// Apply narrows ev to the filter. Snapshots keep only the matching matches;
+// other events pass or fail on their match. It returns false if nothing is
+// left to send.
+func (f *Filter) Apply(ev models.LiveEvent) (models.LiveEvent, bool) {
+	if ev.Type == EventSnapshot {
+		ev.Matches = f.Matches(ev.Matches)
+		return ev, len(ev.Matches) > 0
+	}
+	return ev, f.Match(ev.Match)
+}
+
+func addID(ids []int, id int) []int {
+	if id <= 0 || slices.Contains(ids, id) {
+		return ids
+	}
+	return append(ids, id)
+}
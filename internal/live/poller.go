@@ -0,0 +1,235 @@
+// Package live polls vlr.gg for live matches in the background and fans the
+// resulting score changes out to stream subscribers. A single poller serves
+// every client, including plain /vlr/live requests, and it only polls while
+// someone is subscribed or has recently asked for the live matches.
+package live
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"vlrggapi/pkg/models"
+)
+
+// DefaultInterval is the time between polls when LIVE_POLL_INTERVAL is unset.
+const DefaultInterval = 15 * time.Second
+
+// readKeepAlive is how long the poller keeps polling after a Latest call
+// with nobody subscribed.
+const readKeepAlive = time.Minute
+
+// subscriberBuffer is how many events a subscriber may fall behind before it
+// is dropped.
+const subscriberBuffer = 64
+
+// FetchFunc returns the current live matches.
+type FetchFunc func(ctx context.Context) ([]models.LiveMatch, error)
+
+// Poller periodically fetches the live matches, diffs each snapshot against
+// the previous one and broadcasts the resulting events.
+type Poller struct {
+	interval time.Duration
+	fetch    FetchFunc
+	wake     chan struct{}
+
+	mu       sync.Mutex
+	subs     map[chan models.LiveEvent]struct{}
+	last     []models.LiveMatch
+	polledAt time.Time
+	primed   bool
+	readAt   time.Time
+}
+
+// New returns a Poller that calls fetch every interval. Call Run to start it.
+func New(interval time.Duration, fetch FetchFunc) *Poller {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Poller{
+		interval: interval,
+		fetch:    fetch,
+		wake:     make(chan struct{}, 1),
+		subs:     make(map[chan models.LiveEvent]struct{}),
+	}
+}
+
+// IntervalFromEnv returns LIVE_POLL_INTERVAL, or DefaultInterval when it is
+// unset or invalid.
+func IntervalFromEnv() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("LIVE_POLL_INTERVAL")); err == nil && d > 0 {
+		return d
+	}
+	return DefaultInterval
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultPoller *Poller
+)
+
+// Default returns the process-wide poller, or nil if none was set.
+func Default() *Poller {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultPoller
+}
+
+// SetDefault replaces the process-wide poller. It is intended to be called
+// once during startup.
+func SetDefault(p *Poller) {
+	defaultMu.Lock()
+	defaultPoller = p
+	defaultMu.Unlock()
+}
+
+// Run polls until ctx is cancelled. While there are no subscribers or recent
+// Latest calls it sleeps
+// and forgets the last snapshot, so the first poll after a quiet period is
+// sent as a fresh snapshot rather than diffed against stale state.
+func (p *Poller) Run(ctx context.Context) {
+	for {
+		if !p.active() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.wake:
+				continue
+			}
+		}
+
+		p.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.interval):
+		}
+	}
+}
+
+// Subscribe registers a new subscriber. If a snapshot has already been taken
+// it is delivered first. The channel is closed when cancel is called or when
+// the subscriber falls too far behind.
+func (p *Poller) Subscribe() (<-chan models.LiveEvent, func()) {
+	ch := make(chan models.LiveEvent, subscriberBuffer)
+
+	p.mu.Lock()
+	p.subs[ch] = struct{}{}
+	if p.primed {
+		ch <- snapshot(p.last, time.Now().UTC())
+	}
+	p.mu.Unlock()
+	p.nudge()
+
+	cancel := func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if _, ok := p.subs[ch]; ok {
+			delete(p.subs, ch)
+			close(ch)
+		}
+	}
+	return ch, cancel
+}
+
+// Snapshot returns the most recent live matches and whether a poll has
+// completed since the poller last became active.
+func (p *Poller) Snapshot() ([]models.LiveMatch, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.last), p.primed
+}
+
+// Latest returns the most recent live matches and when they were polled, for
+// requests that do not subscribe. It keeps the poller running for a while
+// after the call; before the first poll it fetches the matches itself.
+func (p *Poller) Latest(ctx context.Context) ([]models.LiveMatch, time.Time, error) {
+	p.mu.Lock()
+	p.readAt = time.Now()
+	matches, polledAt, primed := slices.Clone(p.last), p.polledAt, p.primed
+	p.mu.Unlock()
+	p.nudge()
+	if primed {
+		return matches, polledAt, nil
+	}
+
+	matches, err := p.fetch(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	now := time.Now().UTC()
+	p.mu.Lock()
+	p.update(matches, now)
+	p.mu.Unlock()
+	return slices.Clone(matches), now, nil
+}
+
+// nudge wakes Run if it is sleeping.
+func (p *Poller) nudge() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// active reports whether anyone is subscribed or has recently called Latest,
+// resetting the snapshot when nobody has.
+func (p *Poller) active() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.subs) == 0 && time.Since(p.readAt) > readKeepAlive {
+		p.last, p.primed = nil, false
+		return false
+	}
+	return true
+}
+
+// poll takes one snapshot. Fetch errors are skipped so a single upstream
+// failure does not read as every match ending.
+func (p *Poller) poll(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, p.interval+30*time.Second)
+	defer cancel()
+	matches, err := p.fetch(ctx)
+	if err != nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update(matches, time.Now().UTC())
+}
+
+// update records a new snapshot taken at now and broadcasts what changed.
+// p.mu must be held.
+func (p *Poller) update(matches []models.LiveMatch, now time.Time) {
+	p.polledAt = now
+	if !p.primed {
+		p.last, p.primed = matches, true
+		p.broadcast(snapshot(matches, now))
+		return
+	}
+	events := Diff(p.last, matches, now)
+	p.last = matches
+	for _, ev := range events {
+		p.broadcast(ev)
+	}
+}
+
+// broadcast sends ev to every subscriber without blocking; subscribers whose
+// buffer is full are dropped. p.mu must be held.
+func (p *Poller) broadcast(ev models.LiveEvent) {
+	for ch := range p.subs {
+		select {
+		case ch <- ev:
+		default:
+			delete(p.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+func snapshot(matches []models.LiveMatch, now time.Time) models.LiveEvent {
+	return models.LiveEvent{Type: EventSnapshot, Matches: matches, At: now}
+}
@@ -0,0 +1,117 @@
+package live
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"vlrggapi/pkg/models"
+)
+
+// update records a snapshot on p the way a poll does.
+func update(p *Poller, ms []models.LiveMatch) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update(ms, time.Now().UTC())
+}
+
+func TestSlowSubscriberDropped(t *testing.T) {
+	p := New(time.Minute, nil)
+	slow, cancelSlow := p.Subscribe()
+	defer cancelSlow()
+	fast, cancelFast := p.Subscribe()
+	defer cancelFast()
+
+	received := make(chan int)
+	go func() {
+		n := 0
+		for range fast {
+			n++
+		}
+		received <- n
+	}()
+
+	// One snapshot plus a round per update: more than the slow subscriber's
+	// buffer holds
+	const updates = subscriberBuffer + 10
+	done := make(chan struct{})
+	go func() {
+		for i := range updates {
+			update(p, matches(state{id: 1, rounds1: i, rounds2: 0}))
+			// Keep the fast reader comfortably inside its buffer
+			time.Sleep(100 * time.Microsecond)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("update blocked on a subscriber that is not reading")
+	}
+
+	n := 0
+	for range slow {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Errorf("slow subscriber got %d events before being dropped, want %d", n, subscriberBuffer)
+	}
+	p.mu.Lock()
+	subs := len(p.subs)
+	p.mu.Unlock()
+	if subs != 1 {
+		t.Errorf("%d subscribers left, want only the fast one", subs)
+	}
+
+	cancelFast()
+	if n := <-received; n != updates {
+		t.Errorf("fast subscriber got %d events, want %d", n, updates)
+	}
+	// Cancelling a dropped subscriber is a no-op
+	cancelSlow()
+}
+
+func TestRunSendsSnapshotThenChanges(t *testing.T) {
+	var polls atomic.Int64
+	fetch := func(context.Context) ([]models.LiveMatch, error) {
+		n := int(polls.Add(1))
+		return matches(state{id: 7, rounds1: n, rounds2: 0}), nil
+	}
+	p := New(5*time.Millisecond, fetch)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.Run(ctx)
+
+	events, unsubscribe := p.Subscribe()
+	defer unsubscribe()
+	next := func() models.LiveEvent {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+			return models.LiveEvent{}
+		}
+	}
+	if e := next(); e.Type != EventSnapshot || len(e.Matches) != 1 {
+		t.Fatalf("first event = %+v, want a snapshot of the one match", e)
+	}
+	if e := next(); e.Type != EventRoundWon || e.MatchID != 7 || e.Team != 1 {
+		t.Errorf("second event = %+v, want team 1 winning a round of match 7", e)
+	}
+	if _, primed := p.Snapshot(); !primed {
+		t.Error("Snapshot not primed after polling")
+	}
+}
+
+func TestIdlePollerForgetsSnapshot(t *testing.T) {
+	p := New(time.Minute, nil)
+	update(p, matches(state{id: 1}))
+	if p.active() {
+		t.Fatal("active with no subscribers or reads")
+	}
+	if _, primed := p.Snapshot(); primed {
+		t.Error("an idle poller kept its snapshot; the next poll would be diffed against stale state")
+	}
+}
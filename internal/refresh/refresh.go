@@ -0,0 +1,75 @@
+// Package refresh runs the background scheduler that re-scrapes every
+// refreshable endpoint on its own interval, so requests are answered from
+// the store rather than waiting on vlr.gg.
+package refresh
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+)
+
+// Job is an endpoint the scheduler keeps warm.
+type Job interface {
+	// Name identifies the job in logs and environment overrides.
+	Name() string
+	// Interval is how often every variant is re-scraped.
+	Interval() time.Duration
+	// Variants are the canonical queries to pre-warm; "" is the request
+	// without parameters.
+	Variants() []string
+	// Refresh scrapes one variant into the store.
+	Refresh(ctx context.Context, variant string) error
+}
+
+// ErrorFunc is told about every failed refresh.
+type ErrorFunc func(job, variant string, err error)
+
+// Interval returns the REFRESH_<NAME>_INTERVAL override for a job, e.g.
+// REFRESH_RANKINGS_INTERVAL=2h, or def when it is unset or invalid.
+func Interval(name string, def time.Duration) time.Duration {
+	env := "REFRESH_" + strings.ToUpper(name) + "_INTERVAL"
+	if d, err := time.ParseDuration(os.Getenv(env)); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+// Enabled reports whether the scheduler should run. Set REFRESH_DISABLED=true
+// to scrape on request only.
+func Enabled() bool {
+	disabled := strings.ToLower(os.Getenv("REFRESH_DISABLED"))
+	return disabled != "true" && disabled != "1"
+}
+
+// Run refreshes every job right away and then on its interval until ctx is
+// cancelled. Jobs run concurrently with each other; the variants of a job are
+// refreshed one after another so a job never has more than one scrape in
+// flight.
+func Run(ctx context.Context, jobs []Job, onError ErrorFunc) {
+	for _, job := range jobs {
+		go runJob(ctx, job, onError)
+	}
+	<-ctx.Done()
+}
+
+func runJob(ctx context.Context, job Job, onError ErrorFunc) {
+	ticker := time.NewTicker(job.Interval())
+	defer ticker.Stop()
+	for {
+		for _, variant := range job.Variants() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := job.Refresh(ctx, variant); err != nil && onError != nil {
+				onError(job.Name(), variant, err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
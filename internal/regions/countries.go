@@ -0,0 +1,366 @@
+package regions
+
+import (
+	"strings"
+	"sync"
+)
+
+// Country is a country identified by its ISO 3166-1 alpha-2 code. Code is
+// empty for vlr.gg flags that are not countries, such as Europe or
+// International.
+type Country struct {
+	Code string
+	Name string
+}
+
+// CountryByCode looks up a country by its ISO 3166-1 alpha-2 code, ignoring
+// case.
+func CountryByCode(code string) (Country, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	name, ok := countryNames[code]
+	if !ok {
+		return Country{}, false
+	}
+	return Country{Code: code, Name: name}, true
+}
+
+var (
+	byNameOnce sync.Once
+	byName     map[string]string
+)
+
+// CountryByName looks up a country by the English short name vlr.gg
+// displays (e.g. "United States"), ignoring case.
+func CountryByName(name string) (Country, bool) {
+	byNameOnce.Do(func() {
+		byName = make(map[string]string, len(countryNames)+len(countryAliases))
+		for code, n := range countryNames {
+			byName[strings.ToLower(n)] = code
+		}
+		for alias, code := range countryAliases {
+			byName[alias] = code
+		}
+	})
+	code, ok := byName[strings.ToLower(strings.Join(strings.Fields(name), " "))]
+	if !ok {
+		return Country{}, false
+	}
+	return CountryByCode(code)
+}
+
+// CountryFromFlag converts the class list of a vlr.gg flag icon, e.g.
+// "flag mod-us" or "flag mod-16 mod-br", into a country. Non-country flags
+// vlr.gg uses for regions are returned with an empty Code.
+func CountryFromFlag(class string) (Country, bool) {
+	for _, c := range strings.Fields(class) {
+		code, ok := strings.CutPrefix(c, "mod-")
+		if !ok {
+			continue
+		}
+		if name, ok := flagRegions[code]; ok {
+			return Country{Name: name}, true
+		}
+		if country, ok := CountryByCode(code); ok {
+			return country, true
+		}
+	}
+	return Country{}, false
+}
+
+// flagRegions are vlr.gg flag codes that do not name a country.
+var flagRegions = map[string]string{
+	"eu": "Europe",
+	"un": "International",
+}
+
+// countryAliases are other names a country may be displayed under,
+// including the formal ISO 3166-1 names of countries listed by their common
+// name below.
+var countryAliases = map[string]string{
+	"bolivia, plurinational state of":        "BO",
+	"cape verde":                             "CV",
+	"congo, democratic republic of the":      "CD",
+	"czechia":                                "CZ",
+	"england":                                "GB",
+	"falkland islands (malvinas)":            "FK",
+	"great britain":                          "GB",
+	"hong kong sar":                          "HK",
+	"iran, islamic republic of":              "IR",
+	"ivory coast":                            "CI",
+	"korea":                                  "KR",
+	"korea, democratic people's republic of": "KP",
+	"korea, republic of":                     "KR",
+	"lao people's democratic republic":       "LA",
+	"macao":                                  "MO",
+	"macedonia":                              "MK",
+	"micronesia, federated states of":        "FM",
+	"moldova, republic of":                   "MD",
+	"palestine, state of":                    "PS",
+	"russian federation":                     "RU",
+	"swaziland":                              "SZ",
+	"syrian arab republic":                   "SY",
+	"taiwan, province of china":              "TW",
+	"tanzania, united republic of":           "TZ",
+	"türkiye":                                "TR",
+	"uk":                                     "GB",
+	"united kingdom of great britain and northern ireland": "GB",
+	"united states of america":                             "US",
+	"usa":                                                  "US",
+	"venezuela, bolivarian republic of":                    "VE",
+	"viet nam":                                             "VN",
+}
+
+// countryNames maps ISO 3166-1 alpha-2 codes to common English names, plus
+// the user-assigned XK for Kosovo, which vlr.gg also uses.
+var countryNames = map[string]string{
+	"AD": "Andorra",
+	"AE": "United Arab Emirates",
+	"AF": "Afghanistan",
+	"AG": "Antigua and Barbuda",
+	"AI": "Anguilla",
+	"AL": "Albania",
+	"AM": "Armenia",
+	"AO": "Angola",
+	"AQ": "Antarctica",
+	"AR": "Argentina",
+	"AS": "American Samoa",
+	"AT": "Austria",
+	"AU": "Australia",
+	"AW": "Aruba",
+	"AX": "Åland Islands",
+	"AZ": "Azerbaijan",
+	"BA": "Bosnia and Herzegovina",
+	"BB": "Barbados",
+	"BD": "Bangladesh",
+	"BE": "Belgium",
+	"BF": "Burkina Faso",
+	"BG": "Bulgaria",
+	"BH": "Bahrain",
+	"BI": "Burundi",
+	"BJ": "Benin",
+	"BL": "Saint Barthélemy",
+	"BM": "Bermuda",
+	"BN": "Brunei",
+	"BO": "Bolivia",
+	"BQ": "Bonaire, Sint Eustatius and Saba",
+	"BR": "Brazil",
+	"BS": "Bahamas",
+	"BT": "Bhutan",
+	"BV": "Bouvet Island",
+	"BW": "Botswana",
+	"BY": "Belarus",
+	"BZ": "Belize",
+	"CA": "Canada",
+	"CC": "Cocos (Keeling) Islands",
+	"CD": "DR Congo",
+	"CF": "Central African Republic",
+	"CG": "Congo",
+	"CH": "Switzerland",
+	"CI": "Côte d'Ivoire",
+	"CK": "Cook Islands",
+	"CL": "Chile",
+	"CM": "Cameroon",
+	"CN": "China",
+	"CO": "Colombia",
+	"CR": "Costa Rica",
+	"CU": "Cuba",
+	"CV": "Cabo Verde",
+	"CW": "Curaçao",
+	"CX": "Christmas Island",
+	"CY": "Cyprus",
+	"CZ": "Czech Republic",
+	"DE": "Germany",
+	"DJ": "Djibouti",
+	"DK": "Denmark",
+	"DM": "Dominica",
+	"DO": "Dominican Republic",
+	"DZ": "Algeria",
+	"EC": "Ecuador",
+	"EE": "Estonia",
+	"EG": "Egypt",
+	"EH": "Western Sahara",
+	"ER": "Eritrea",
+	"ES": "Spain",
+	"ET": "Ethiopia",
+	"FI": "Finland",
+	"FJ": "Fiji",
+	"FK": "Falkland Islands",
+	"FM": "Micronesia",
+	"FO": "Faroe Islands",
+	"FR": "France",
+	"GA": "Gabon",
+	"GB": "United Kingdom",
+	"GD": "Grenada",
+	"GE": "Georgia",
+	"GF": "French Guiana",
+	"GG": "Guernsey",
+	"GH": "Ghana",
+	"GI": "Gibraltar",
+	"GL": "Greenland",
+	"GM": "Gambia",
+	"GN": "Guinea",
+	"GP": "Guadeloupe",
+	"GQ": "Equatorial Guinea",
+	"GR": "Greece",
+	"GS": "South Georgia and the South Sandwich Islands",
+	"GT": "Guatemala",
+	"GU": "Guam",
+	"GW": "Guinea-Bissau",
+	"GY": "Guyana",
+	"HK": "Hong Kong",
+	"HM": "Heard Island and McDonald Islands",
+	"HN": "Honduras",
+	"HR": "Croatia",
+	"HT": "Haiti",
+	"HU": "Hungary",
+	"ID": "Indonesia",
+	"IE": "Ireland",
+	"IL": "Israel",
+	"IM": "Isle of Man",
+	"IN": "India",
+	"IO": "British Indian Ocean Territory",
+	"IQ": "Iraq",
+	"IR": "Iran",
+	"IS": "Iceland",
+	"IT": "Italy",
+	"JE": "Jersey",
+	"JM": "Jamaica",
+	"JO": "Jordan",
+	"JP": "Japan",
+	"KE": "Kenya",
+	"KG": "Kyrgyzstan",
+	"KH": "Cambodia",
+	"KI": "Kiribati",
+	"KM": "Comoros",
+	"KN": "Saint Kitts and Nevis",
+	"KP": "North Korea",
+	"KR": "South Korea",
+	"KW": "Kuwait",
+	"KY": "Cayman Islands",
+	"KZ": "Kazakhstan",
+	"LA": "Laos",
+	"LB": "Lebanon",
+	"LC": "Saint Lucia",
+	"LI": "Liechtenstein",
+	"LK": "Sri Lanka",
+	"LR": "Liberia",
+	"LS": "Lesotho",
+	"LT": "Lithuania",
+	"LU": "Luxembourg",
+	"LV": "Latvia",
+	"LY": "Libya",
+	"MA": "Morocco",
+	"MC": "Monaco",
+	"MD": "Moldova",
+	"ME": "Montenegro",
+	"MF": "Saint Martin (French part)",
+	"MG": "Madagascar",
+	"MH": "Marshall Islands",
+	"MK": "North Macedonia",
+	"ML": "Mali",
+	"MM": "Myanmar",
+	"MN": "Mongolia",
+	"MO": "Macau",
+	"MP": "Northern Mariana Islands",
+	"MQ": "Martinique",
+	"MR": "Mauritania",
+	"MS": "Montserrat",
+	"MT": "Malta",
+	"MU": "Mauritius",
+	"MV": "Maldives",
+	"MW": "Malawi",
+	"MX": "Mexico",
+	"MY": "Malaysia",
+	"MZ": "Mozambique",
+	"NA": "Namibia",
+	"NC": "New Caledonia",
+	"NE": "Niger",
+	"NF": "Norfolk Island",
+	"NG": "Nigeria",
+	"NI": "Nicaragua",
+	"NL": "Netherlands",
+	"NO": "Norway",
+	"NP": "Nepal",
+	"NR": "Nauru",
+	"NU": "Niue",
+	"NZ": "New Zealand",
+	"OM": "Oman",
+	"PA": "Panama",
+	"PE": "Peru",
+	"PF": "French Polynesia",
+	"PG": "Papua New Guinea",
+	"PH": "Philippines",
+	"PK": "Pakistan",
+	"PL": "Poland",
+	"PM": "Saint Pierre and Miquelon",
+	"PN": "Pitcairn",
+	"PR": "Puerto Rico",
+	"PS": "Palestine",
+	"PT": "Portugal",
+	"PW": "Palau",
+	"PY": "Paraguay",
+	"QA": "Qatar",
+	"RE": "Réunion",
+	"RO": "Romania",
+	"RS": "Serbia",
+	"RU": "Russia",
+	"RW": "Rwanda",
+	"SA": "Saudi Arabia",
+	"SB": "Solomon Islands",
+	"SC": "Seychelles",
+	"SD": "Sudan",
+	"SE": "Sweden",
+	"SG": "Singapore",
+	"SH": "Saint Helena, Ascension and Tristan da Cunha",
+	"SI": "Slovenia",
+	"SJ": "Svalbard and Jan Mayen",
+	"SK": "Slovakia",
+	"SL": "Sierra Leone",
+	"SM": "San Marino",
+	"SN": "Senegal",
+	"SO": "Somalia",
+	"SR": "Suriname",
+	"SS": "South Sudan",
+	"ST": "Sao Tome and Principe",
+	"SV": "El Salvador",
+	"SX": "Sint Maarten (Dutch part)",
+	"SY": "Syria",
+	"SZ": "Eswatini",
+	"TC": "Turks and Caicos Islands",
+	"TD": "Chad",
+	"TF": "French Southern Territories",
+	"TG": "Togo",
+	"TH": "Thailand",
+	"TJ": "Tajikistan",
+	"TK": "Tokelau",
+	"TL": "Timor-Leste",
+	"TM": "Turkmenistan",
+	"TN": "Tunisia",
+	"TO": "Tonga",
+	"TR": "Turkey",
+	"TT": "Trinidad and Tobago",
+	"TV": "Tuvalu",
+	"TW": "Taiwan",
+	"TZ": "Tanzania",
+	"UA": "Ukraine",
+	"UG": "Uganda",
+	"UM": "United States Minor Outlying Islands",
+	"US": "United States",
+	"UY": "Uruguay",
+	"UZ": "Uzbekistan",
+	"VA": "Holy See",
+	"VC": "Saint Vincent and the Grenadines",
+	"VE": "Venezuela",
+	"VG": "Virgin Islands (British)",
+	"VI": "Virgin Islands (U.S.)",
+	"VN": "Vietnam",
+	"VU": "Vanuatu",
+	"WF": "Wallis and Futuna",
+	"WS": "Samoa",
+	"XK": "Kosovo",
+	"YE": "Yemen",
+	"YT": "Mayotte",
+	"ZA": "South Africa",
+	"ZM": "Zambia",
+	"ZW": "Zimbabwe",
+}
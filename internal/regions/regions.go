@@ -0,0 +1,57 @@
+// Package regions describes the regions vlr.gg groups teams and stats by,
+// and the countries behind its flag icons.
+package regions
+
+import "strings"
+
+// Region is a vlr.gg region. vlr.gg names the same region differently per
+// page: the rankings page uses a long slug in the path (/rankings/europe)
+// while the stats page takes a short code as a query value (region=eu).
+type Region struct {
+	// Key is the short code this API accepts, e.g. "eu".
+	Key  string
+	Name string
+	// RankingsSlug is the path segment of the rankings page.
+	RankingsSlug string
+	// StatsSlug is the region value of the stats page.
+	StatsSlug string
+}
+
+// All lists every vlr.gg region in the order vlr.gg shows them.
+var All = []Region{
+	{Key: "na", Name: "North America", RankingsSlug: "north-america", StatsSlug: "na"},
+	{Key: "eu", Name: "Europe", RankingsSlug: "europe", StatsSlug: "eu"},
+	{Key: "br", Name: "Brazil", RankingsSlug: "brazil", StatsSlug: "br"},
+	{Key: "ap", Name: "Asia-Pacific", RankingsSlug: "asia-pacific", StatsSlug: "ap"},
+	{Key: "kr", Name: "Korea", RankingsSlug: "korea", StatsSlug: "kr"},
+	{Key: "cn", Name: "China", RankingsSlug: "china", StatsSlug: "cn"},
+	{Key: "jp", Name: "Japan", RankingsSlug: "japan", StatsSlug: "jp"},
+	{Key: "la", Name: "Latin America", RankingsSlug: "latin-america", StatsSlug: "la"},
+	{Key: "la-s", Name: "Latin America South", RankingsSlug: "la-s", StatsSlug: "la-s"},
+	{Key: "la-n", Name: "Latin America North", RankingsSlug: "la-n", StatsSlug: "la-n"},
+	{Key: "oce", Name: "Oceania", RankingsSlug: "oceania", StatsSlug: "oce"},
+	{Key: "mn", Name: "MENA", RankingsSlug: "mena", StatsSlug: "mn"},
+	{Key: "gc", Name: "Game Changers", RankingsSlug: "gc", StatsSlug: "gc"},
+	{Key: "col", Name: "Collegiate", RankingsSlug: "collegiate", StatsSlug: "col"},
+}
+
+// Lookup finds a region by key, rankings slug or display name, ignoring
+// case.
+func Lookup(s string) (Region, bool) {
+	s = strings.TrimSpace(s)
+	for _, r := range All {
+		if strings.EqualFold(s, r.Key) || strings.EqualFold(s, r.RankingsSlug) || strings.EqualFold(s, r.Name) {
+			return r, true
+		}
+	}
+	return Region{}, false
+}
+
+// Keys returns the region keys in display order.
+func Keys() []string {
+	keys := make([]string, len(All))
+	for i, r := range All {
+		keys[i] = r.Key
+	}
+	return keys
+}
@@ -0,0 +1,114 @@
+package regions
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantKey string
+	}{
+		{"eu", "eu"},
+		{"EU", "eu"},
+		{" na ", "na"},
+		{"north-america", "na"},
+		{"North America", "na"},
+		{"asia-pacific", "ap"},
+		{"Asia-Pacific", "ap"},
+		{"la-s", "la-s"},
+		{"Latin America North", "la-n"},
+		{"oceania", "oce"},
+		{"MENA", "mn"},
+		{"collegiate", "col"},
+		{"game changers", "gc"},
+		{"", ""},
+		{"mars", ""},
+		{"north america ", "na"},
+		{"northamerica", ""},
+		{"la", "la"},
+		{"latin", ""},
+	}
+	for _, tt := range tests {
+		r, ok := Lookup(tt.in)
+		if ok != (tt.wantKey != "") || r.Key != tt.wantKey {
+			t.Errorf("Lookup(%q) = %q, %v; want %q", tt.in, r.Key, ok, tt.wantKey)
+		}
+	}
+}
+
+func TestCountryByCode(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Country
+		ok   bool
+	}{
+		{"US", Country{"US", "United States"}, true},
+		{"us", Country{"US", "United States"}, true},
+		{" gb ", Country{"GB", "United Kingdom"}, true},
+		{"XK", Country{"XK", "Kosovo"}, true},
+		{"EU", Country{}, false},
+		{"UN", Country{}, false},
+		{"UK", Country{}, false},
+		{"USA", Country{}, false},
+		{"", Country{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := CountryByCode(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CountryByCode(%q) = %+v, %v; want %+v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCountryByName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"United States", "US"},
+		{"united  states", "US"},
+		{"USA", "US"},
+		{"United States of America", "US"},
+		{"England", "GB"},
+		{"UK", "GB"},
+		{"Korea", "KR"},
+		{"Korea, Republic of", "KR"},
+		{"Türkiye", "TR"},
+		{"Viet Nam", "VN"},
+		{"Côte d'Ivoire", "CI"},
+		{"Ivory Coast", "CI"},
+		{"Europe", ""},
+		{"Narnia", ""},
+	}
+	for _, tt := range tests {
+		got, ok := CountryByName(tt.in)
+		if ok != (tt.want != "") || got.Code != tt.want {
+			t.Errorf("CountryByName(%q) = %+v, %v; want %q", tt.in, got, ok, tt.want)
+		}
+	}
+}
+
+func TestCountryFromFlag(t *testing.T) {
+	tests := []struct {
+		class string
+		want  Country
+		ok    bool
+	}{
+		{"flag mod-us", Country{"US", "United States"}, true},
+		{"flag mod-16 mod-br", Country{"BR", "Brazil"}, true},
+		{"mod-kr flag", Country{"KR", "South Korea"}, true},
+		{"flag mod-eu", Country{Name: "Europe"}, true},
+		{"flag mod-un", Country{Name: "International"}, true},
+		{"flag mod-xk", Country{"XK", "Kosovo"}, true},
+		{"flag mod-zz", Country{}, false},
+		{"flag mod-", Country{}, false},
+		{"flag", Country{}, false},
+		{"", Country{}, false},
+		{"flag us", Country{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := CountryFromFlag(tt.class)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CountryFromFlag(%q) = %+v, %v; want %+v, %v", tt.class, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"crypto/subtle"
+	"os"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// requireAdmin guards write and management endpoints with the ADMIN_TOKEN
+// environment variable, sent as "Authorization: Bearer <token>". Without
+// ADMIN_TOKEN set the endpoints are disabled.
+func requireAdmin() fiber.Handler {
+	token := os.Getenv("ADMIN_TOKEN")
+	return func(c *fiber.Ctx) error {
+		if token == "" {
+			return c.Status(503).JSON(fiber.Map{"error": "Admin endpoints are disabled; set ADMIN_TOKEN to enable them"})
+		}
+		given, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			return c.Status(401).JSON(fiber.Map{"error": "Unauthorized"})
+		}
+		return c.Next()
+	}
+}
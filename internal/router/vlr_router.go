@@ -1,6 +1,7 @@
 package router
 
 import (
+	"vlrggapi/internal/cache"
 	"vlrggapi/internal/scrapers"
 	"github.com/gofiber/fiber/v2"
 )
@@ -8,17 +9,41 @@ import (
 func RegisterVlrRoutes(app *fiber.App) {
 	vlr := app.Group("/vlr")
 
-	// Register all modular scrapers
+	// Register all modular scrapers (news, stats, rankings, match, live, events)
+	// Scrapers with their own cache policy override the cache default
 	for _, s := range scrapers.Registry {
+		if cs, ok := s.(scrapers.Cached); ok {
+			vlr.Get(s.Route(), cache.WithPolicy(cs.CachePolicy()), s.Handler())
+			continue
+		}
 		vlr.Get(s.Route(), s.Handler())
 	}
 
 	// Legacy/manual endpoints (for backward compatibility or not yet modularized)
-	vlr.Get("/news", scrapers.VlrNews)
-	vlr.Get("/stats", scrapers.VlrStats)
-	vlr.Get("/rankings", scrapers.VlrRankings)
-	vlr.Get("/match", scrapers.VlrMatchResults)
-	vlr.Get("/live", scrapers.VlrLiveScore)
-	vlr.Get("/events", scrapers.VlrEvents)
+	vlr.Get("/match/:id", scrapers.VlrMatchDetail)
+	vlr.Get("/match/:id/rounds", scrapers.VlrMatchRounds)
+	vlr.Get("/player/:id", scrapers.VlrPlayer)
+	vlr.Get("/team/:id", scrapers.VlrTeam)
+	vlr.Get("/live/stream", scrapers.VlrLiveStream)
+	vlr.Get("/live/ws", scrapers.VlrLiveSocket)
+	vlr.Get("/event/:id", scrapers.VlrEventDetail)
+	vlr.Get("/event/:id/matches", scrapers.VlrEventMatches)
+	vlr.Get("/event/:id/stats", scrapers.VlrEventStats)
 	vlr.Get("/health", scrapers.Health)
+
+	// Webhook management, behind ADMIN_TOKEN
+	hooks := vlr.Group("/webhooks", requireAdmin())
+	hooks.Post("/", scrapers.VlrWebhookCreate)
+	hooks.Get("/", scrapers.VlrWebhooks)
+	hooks.Get("/:id", scrapers.VlrWebhook)
+	hooks.Delete("/:id", scrapers.VlrWebhookDelete)
+	hooks.Post("/:id/test", scrapers.VlrWebhookTest)
+	hooks.Get("/:id/deliveries", scrapers.VlrWebhookDeliveries)
+
+	// Operational statistics and cache management, behind ADMIN_TOKEN
+	admin := vlr.Group("/admin", requireAdmin())
+	admin.Get("/fetch", scrapers.VlrAdminFetch)
+	admin.Get("/cache", scrapers.VlrAdminCache)
+	admin.Post("/cache/purge", scrapers.VlrAdminCachePurge)
+	admin.Get("/cache/stats", scrapers.VlrAdminCacheStats)
 }
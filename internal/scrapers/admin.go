@@ -0,0 +1,207 @@
+package scrapers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/fetch"
+	"vlrggapi/pkg/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//
+// VlrAdminFetch godoc
+// @Summary      Upstream fetch statistics
+// @Description  Returns how many upstream requests the scrapers made (requests), how many vlr.gg fetches they caused (fetches) and how many joined a fetch already in flight for the same URL instead of starting their own (deduplicated), and how many fetches vlr.gg answered with 304 Not Modified (not_modified). Counters are since process start.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  models.SegmentsResponse[models.FetchStats]
+// @Failure      401  {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/admin/fetch [get]
+//
+func VlrAdminFetch(c *fiber.Ctx) error {
+	s := fetch.Default().Stats()
+	return c.JSON(models.NewSegments(200, []models.FetchStats{{
+		Requests:     s.Requests,
+		Fetches:      s.Fetches,
+		Deduplicated: s.Deduplicated,
+		InFlight:     s.InFlight,
+		NotModified:  s.NotModified,
+	}}))
+}
+
+//
+// VlrAdminCache godoc
+// @Summary      List cached responses
+// @Description  Returns every cached response whose key (the request URL, e.g. /vlr/match?q=results) starts with prefix, sorted by key, with its route, size in bytes, age and policy in seconds, and whether it is fresh or stale.
+// @Tags         admin
+// @Produce      json
+// @Param        prefix  query     string  false  "Only keys starting with this, e.g. /vlr/match"
+// @Success      200     {object}  models.SegmentsResponse[models.CacheKey]
+// @Failure      401     {object}  models.ErrorResponse
+// @Failure      500     {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/admin/cache [get]
+//
+func VlrAdminCache(c *fiber.Ctx) error {
+	responses := cache.Default()
+	if responses == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Cache is not available"})
+	}
+	keys, err := responses.Keys(c.UserContext(), c.Query("prefix"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to list cache keys"})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+	now := time.Now()
+	items := make([]models.CacheKey, 0, len(keys))
+	for _, k := range keys {
+		if strings.HasPrefix(k.Key, purgeMarkerPrefix) {
+			continue
+		}
+		age := now.Sub(k.StoredAt)
+		state := "fresh"
+		if age >= k.TTL {
+			state = "stale"
+		}
+		items = append(items, models.CacheKey{
+			Key:      k.Key,
+			Route:    k.Route,
+			Size:     k.Size,
+			Age:      int64(age / time.Second),
+			StoredAt: k.StoredAt.UTC(),
+			TTL:      int64(k.TTL / time.Second),
+			Stale:    int64(k.Stale / time.Second),
+			State:    state,
+		})
+	}
+	return c.JSON(models.NewSegments(200, items))
+}
+
+//
+// VlrAdminCachePurge godoc
+// @Summary      Purge cached responses
+// @Description  Removes one cached response by exact key, or every cached response whose key starts with prefix (e.g. /vlr/match). Background-refreshed endpoints also drop their stored data, on every replica sharing the cache, so the next request scrapes vlr.gg again: a key drops the stored copy of that query, a prefix every stored copy under it.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        purge  body      models.CachePurgeRequest  true  "Key or prefix to purge"
+// @Success      200    {object}  models.SegmentsResponse[models.CachePurgeResult]
+// @Failure      400    {object}  models.ErrorResponse
+// @Failure      401    {object}  models.ErrorResponse
+// @Failure      500    {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/admin/cache/purge [post]
+//
+func VlrAdminCachePurge(c *fiber.Ctx) error {
+	responses := cache.Default()
+	if responses == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Cache is not available"})
+	}
+	var req models.CachePurgeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if (req.Key == "") == (req.Prefix == "") {
+		return c.Status(400).JSON(fiber.Map{"error": "Exactly one of key or prefix is required"})
+	}
+
+	ctx := c.UserContext()
+	var result models.CachePurgeResult
+	if req.Key != "" {
+		_, found, err := responses.Get(ctx, req.Key)
+		if err == nil {
+			err = responses.Delete(ctx, req.Key)
+		}
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to purge cache"})
+		}
+		if found {
+			result.Purged = 1
+		}
+		result.Scrapers, err = purgeStored(ctx, responses, req.Key, false)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to purge cache"})
+		}
+	} else {
+		n, err := responses.DeletePrefix(ctx, req.Prefix)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to purge cache"})
+		}
+		result.Purged = n
+		result.Scrapers, err = purgeStored(ctx, responses, req.Prefix, true)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to purge cache"})
+		}
+	}
+	return c.JSON(models.NewSegments(200, []models.CachePurgeResult{result}))
+}
+
+// purger is a scraper keeping data outside the response cache that a purge
+// must also drop.
+type purger interface {
+	Refreshable
+	purge(ctx context.Context, responses cache.Cache, query string, all bool) (bool, error)
+}
+
+// purgeStored drops the stored scrapes of the background-refreshed scrapers
+// whose route the purged key or prefix covers, and returns their names. A key
+// drops only the stored variant it names; a prefix drops every variant.
+func purgeStored(ctx context.Context, responses cache.Cache, purged string, prefix bool) ([]string, error) {
+	path, query, _ := strings.Cut(purged, "?")
+	names := []string{}
+	for _, s := range Registry {
+		p, ok := s.(purger)
+		if !ok {
+			continue
+		}
+		// Registry routes are mounted under /vlr
+		route := "/vlr" + p.Route()
+		var dropped bool
+		var err error
+		switch {
+		case prefix && strings.HasPrefix(route, path):
+			dropped, err = p.purge(ctx, responses, "", true)
+		case !prefix && path == route:
+			dropped, err = p.purge(ctx, responses, query, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if dropped {
+			names = append(names, p.Name())
+		}
+	}
+	return names, nil
+}
+
+//
+// VlrAdminCacheStats godoc
+// @Summary      Cache hit/miss statistics
+// @Description  Returns cache lookups per route pattern since process start: hits, stale hits served while refreshing, misses, and the share answered from the cache (hit_ratio, stale included). Counts are for the replica that answers, even when the cache itself is shared.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  models.SegmentsResponse[models.CacheRouteStats]
+// @Failure      401  {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/admin/cache/stats [get]
+//
+func VlrAdminCacheStats(c *fiber.Ctx) error {
+	routes := cache.DefaultStats().Routes()
+	items := make([]models.CacheRouteStats, 0, len(routes))
+	for _, r := range routes {
+		items = append(items, models.CacheRouteStats{
+			Route:    r.Route,
+			Hits:     r.Hits,
+			Stale:    r.Stale,
+			Misses:   r.Misses,
+			HitRatio: r.HitRatio(),
+		})
+	}
+	return c.JSON(models.NewSegments(200, items))
+}
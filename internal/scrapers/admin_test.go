@@ -0,0 +1,229 @@
+package scrapers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/store"
+	"vlrggapi/pkg/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// sharedMemory is a memory cache standing in for Redis, shared by replicas.
+type sharedMemory struct{ *cache.Memory }
+
+func (sharedMemory) Shared() bool { return true }
+
+// purgeFixture serves a stored scraper at /vlr/test, with pre-warmed
+// variants page=1 and page=2, behind the response cache, together with the
+// admin cache endpoints. Scrapes answer with their call number as the title.
+type purgeFixture struct {
+	app       *fiber.App
+	responses cache.Cache
+	scrapes   atomic.Int64
+}
+
+func newPurgeFixture(t *testing.T, responses cache.Cache) *purgeFixture {
+	f := &purgeFixture{responses: responses}
+	s := &storedScraper[models.SegmentsResponse[models.NewsArticle]]{
+		name:     "test",
+		route:    "/test",
+		interval: time.Hour,
+		variants: []string{"", "page=2"},
+		key: func(q map[string]string) (string, error) {
+			page := q["page"]
+			if page == "" {
+				page = "1"
+			}
+			return "page=" + page, nil
+		},
+		scrape: func(context.Context, map[string]string) (models.SegmentsResponse[models.NewsArticle], error) {
+			n := f.scrapes.Add(1)
+			return models.NewSegments(200, []models.NewsArticle{{Title: strconv.FormatInt(n, 10)}}), nil
+		},
+		stamp: stampSegments[models.NewsArticle],
+	}
+
+	saved, savedCache := Registry, cache.Default()
+	Registry = []Scraper{s}
+	cache.SetDefault(responses)
+	t.Cleanup(func() {
+		Registry = saved
+		cache.SetDefault(savedCache)
+		for _, key := range []string{"test?page=1", "test?page=2"} {
+			store.Default().Delete(key)
+		}
+	})
+
+	f.app = fiber.New()
+	f.app.Use(cache.Middleware(responses, cache.Policy{TTL: time.Hour}, cache.NewStats(), nil))
+	f.app.Get("/vlr/test", s.serve)
+	f.app.Get("/vlr/admin/cache", VlrAdminCache)
+	f.app.Post("/vlr/admin/cache/purge", VlrAdminCachePurge)
+	return f
+}
+
+// do sends a request, with an admin token so the response cache leaves
+// admin requests alone, and returns the segments of the response.
+func (f *purgeFixture) do(t *testing.T, method, target, body string) []json.RawMessage {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if strings.HasPrefix(target, "/vlr/admin") {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer test")
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	resp, err := f.app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		t.Fatalf("%s %s: %d %s", method, target, resp.StatusCode, data)
+	}
+	var env models.SegmentsResponse[json.RawMessage]
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatal(err)
+	}
+	return env.Data.Segments
+}
+
+// one decodes the single segment of a response into v.
+func one(t *testing.T, segments []json.RawMessage, v any) {
+	t.Helper()
+	if len(segments) != 1 {
+		t.Fatalf("%d segments, want 1", len(segments))
+	}
+	if err := json.Unmarshal(segments[0], v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *purgeFixture) title(t *testing.T, target string) string {
+	t.Helper()
+	var article models.NewsArticle
+	one(t, f.do(t, "GET", target, ""), &article)
+	return article.Title
+}
+
+func (f *purgeFixture) purge(t *testing.T, body string) models.CachePurgeResult {
+	t.Helper()
+	var result models.CachePurgeResult
+	one(t, f.do(t, "POST", "/vlr/admin/cache/purge", body), &result)
+	return result
+}
+
+// listed returns the keys the admin cache listing shows.
+func (f *purgeFixture) listed(t *testing.T) []string {
+	t.Helper()
+	keys := []string{}
+	for _, segment := range f.do(t, "GET", "/vlr/admin/cache", "") {
+		var item models.CacheKey
+		if err := json.Unmarshal(segment, &item); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, item.Key)
+	}
+	return keys
+}
+
+func TestPurgeKeyDropsOnlyThatVariant(t *testing.T) {
+	f := newPurgeFixture(t, sharedMemory{cache.NewMemory(0, 0, 0)})
+	f.title(t, "/vlr/test")
+	f.title(t, "/vlr/test?page=2")
+
+	result := f.purge(t, `{"key":"/vlr/test?page=2"}`)
+	if result.Purged != 1 || !slices.Equal(result.Scrapers, []string{"test"}) {
+		t.Errorf("purge = %+v, want one response and the test scraper", result)
+	}
+	if keys := f.listed(t); !slices.Equal(keys, []string{"/vlr/test"}) {
+		t.Errorf("listed %v, want only /vlr/test; markers stay hidden", keys)
+	}
+	markers, _ := f.responses.Keys(context.Background(), purgeMarkerPrefix)
+	if len(markers) != 1 || markers[0].Key != purgeKey("test", "page=2") {
+		t.Errorf("markers %v, want one for page=2", markers)
+	}
+	if _, ok := store.Default().Get("test?page=1"); !ok {
+		t.Error("purging page=2 dropped the stored page=1")
+	}
+
+	// Another replica still holds the page=2 scrape from before the purge;
+	// the marker makes it scrape again instead of re-caching the old copy
+	store.Default().Set("test?page=2", store.Entry{
+		Value:     models.NewSegments(200, []models.NewsArticle{{Title: "old"}}),
+		FetchedAt: time.Now().Add(-time.Minute),
+	})
+	if got := f.title(t, "/vlr/test?page=2"); got != "3" {
+		t.Errorf("page=2 after purge = %q, want the third scrape", got)
+	}
+	if got := f.title(t, "/vlr/test"); got != "1" {
+		t.Errorf("page=1 after purge = %q, want the first scrape still cached", got)
+	}
+	if n := f.scrapes.Load(); n != 3 {
+		t.Errorf("scraped %d times, want 3", n)
+	}
+
+	// A scrape made after the purge is served from the store again
+	if err := f.responses.Delete(context.Background(), "/vlr/test?page=2"); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.title(t, "/vlr/test?page=2"); got != "3" {
+		t.Errorf("page=2 from the store = %q, want the third scrape", got)
+	}
+}
+
+func TestPurgePrefixDropsEveryVariant(t *testing.T) {
+	f := newPurgeFixture(t, sharedMemory{cache.NewMemory(0, 0, 0)})
+	f.title(t, "/vlr/test")
+	f.title(t, "/vlr/test?page=2")
+
+	result := f.purge(t, `{"prefix":"/vlr/te"}`)
+	if result.Purged != 2 || !slices.Equal(result.Scrapers, []string{"test"}) {
+		t.Errorf("purge = %+v, want two responses and the test scraper", result)
+	}
+	if keys := f.listed(t); len(keys) != 0 {
+		t.Errorf("listed %v, want nothing", keys)
+	}
+	if got := f.title(t, "/vlr/test"); got != "3" {
+		t.Errorf("page=1 after purge = %q, want the third scrape", got)
+	}
+	if got := f.title(t, "/vlr/test?page=2"); got != "4" {
+		t.Errorf("page=2 after purge = %q, want the fourth scrape", got)
+	}
+}
+
+func TestPurgeUnstoredQuery(t *testing.T) {
+	f := newPurgeFixture(t, sharedMemory{cache.NewMemory(0, 0, 0)})
+	f.title(t, "/vlr/test?page=3")
+
+	result := f.purge(t, `{"key":"/vlr/test?page=3"}`)
+	if result.Purged != 1 || len(result.Scrapers) != 0 {
+		t.Errorf("purge = %+v, want the response only; page=3 is never stored", result)
+	}
+	if keys, _ := f.responses.Keys(context.Background(), purgeMarkerPrefix); len(keys) != 0 {
+		t.Errorf("wrote markers %v for a query that is not stored", keys)
+	}
+}
+
+func TestPurgeWithoutSharedCacheWritesNoMarkers(t *testing.T) {
+	f := newPurgeFixture(t, cache.NewMemory(0, 0, 0))
+	f.title(t, "/vlr/test")
+
+	f.purge(t, `{"prefix":"/vlr/test"}`)
+	if keys, _ := f.responses.Keys(context.Background(), purgeMarkerPrefix); len(keys) != 0 {
+		t.Errorf("wrote markers %v to a cache no other replica reads", keys)
+	}
+	if got := f.title(t, "/vlr/test"); got != "2" {
+		t.Errorf("after purge = %q, want the second scrape", got)
+	}
+}
@@ -0,0 +1,122 @@
+package scrapers
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"vlrggapi/internal/regions"
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+)
+
+// Helpers turning scraped display strings into the typed model fields. Each
+// returns nil when the value is missing or unparseable so it serialises as
+// JSON null.
+
+func parseMoney(s string) *models.Money {
+	cents, currency, ok := utils.ParseMoney(s)
+	if !ok {
+		return nil
+	}
+	return &models.Money{AmountCents: cents, Currency: currency}
+}
+
+func parseRecord(s string) *models.Record {
+	wins, losses, ok := utils.ParseRecord(s)
+	if !ok {
+		return nil
+	}
+	return &models.Record{Wins: wins, Losses: losses}
+}
+
+// clock is the current time relative dates such as "2d ago" are resolved
+// against by the parsers. Tests pin it.
+var clock = time.Now
+
+func parseRelativeTime(s string, now time.Time) *time.Time {
+	t, ok := utils.ParseRelativeTime(s, now)
+	if !ok {
+		return nil
+	}
+	return &t
+}
+
+func parseDate(s string) *time.Time {
+	t, ok := utils.ParseDate(s)
+	if !ok {
+		return nil
+	}
+	return &t
+}
+
+// country resolves a vlr.gg flag class list (e.g. "flag mod-us") and/or a
+// displayed country name. An unrecognised but non-empty name is kept as is
+// with an empty code.
+func country(flagClass, name string) *models.Country {
+	if c, ok := regions.CountryFromFlag(flagClass); ok {
+		return &models.Country{Code: c.Code, Name: c.Name}
+	}
+	name = cleanText(name)
+	if c, ok := regions.CountryByName(name); ok {
+		return &models.Country{Code: c.Code, Name: c.Name}
+	}
+	if name == "" {
+		return nil
+	}
+	return &models.Country{Name: name}
+}
+
+// rawStrings builds a Raw map from key/value pairs, trimming values and
+// dropping empty ones.
+func rawStrings(kv ...string) map[string]string {
+	raw := make(map[string]string, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		if v := strings.TrimSpace(kv[i+1]); v != "" {
+			raw[kv[i]] = v
+		}
+	}
+	return raw
+}
+
+// parseUTCTimestamp accepts both unix seconds and the "2006-01-02 15:04:05"
+// form vlr.gg uses in data-utc-ts attributes.
+func parseUTCTimestamp(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		t := time.Unix(sec, 0).UTC()
+		return &t
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05", s); err == nil {
+		return &t
+	}
+	return nil
+}
+
+// parseCredits parses an in-game credit amount such as "9.4k" or "800".
+func parseCredits(s string) *int {
+	s = strings.ToLower(strings.TrimSpace(s))
+	mult := 1.0
+	if strings.HasSuffix(s, "k") {
+		s, mult = strings.TrimSuffix(s, "k"), 1000
+	}
+	f := utils.ParseFloat(s)
+	if f == nil {
+		return nil
+	}
+	n := int(math.Round(*f * mult))
+	return &n
+}
+
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func firstField(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
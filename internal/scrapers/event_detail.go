@@ -0,0 +1,397 @@
+package scrapers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gofiber/fiber/v2"
+)
+
+//
+// VlrEventDetail godoc
+// @Summary      Get a single event
+// @Description  Returns an event's stages with group standings and bracket trees, participating teams with seeds and the prize distribution
+// @Tags         events
+// @Produce      json
+// @Param        id   path      int  true  "vlr.gg event ID"
+// @Success      200  {object}  models.SegmentsResponse[models.EventDetail]
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /vlr/event/{id} [get]
+//
+func VlrEventDetail(c *fiber.Ctx) error {
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || eventID <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid event id"})
+	}
+
+	resp, err := fetch.Get(c.UserContext(), fmt.Sprintf("%s/event/%d/", utils.BaseURL(), eventID))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch event"})
+	}
+	if resp.StatusCode == fiber.StatusNotFound {
+		return c.Status(404).JSON(fiber.Map{"error": "Event not found"})
+	}
+	event, err := ParseEventDetail(bytes.NewReader(resp.Body))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+	}
+	if event.EventID == 0 {
+		event.EventID = eventID
+	}
+
+	return c.JSON(models.NewSegments(resp.StatusCode, []models.EventDetail{event}))
+}
+
+// ParseEventDetail extracts the header, stages, teams and prize distribution
+// from a vlr.gg event overview page.
+func ParseEventDetail(r io.Reader) (models.EventDetail, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return models.EventDetail{}, err
+	}
+
+	var e models.EventDetail
+	canonical := doc.Find("link[rel='canonical']").AttrOr("href", "")
+	e.EventID, e.Slug = utils.EntityID(canonical, "event")
+	e.URL = utils.AbsoluteURL(canonical)
+
+	header := doc.Find(".event-header")
+	e.Name = cleanText(header.Find("h1.wf-title").Text())
+	e.Subtitle = cleanText(header.Find("h2.event-desc-subtitle").Text())
+	e.Logo = utils.AbsoluteURL(header.Find(".event-header-thumb img").AttrOr("src", ""))
+
+	var prize string
+	header.Find(".event-desc-item").Each(func(_ int, item *goquery.Selection) {
+		value := cleanText(item.Find(".event-desc-item-value").Text())
+		switch strings.ToLower(cleanText(item.Find(".event-desc-item-label").Text())) {
+		case "dates":
+			e.Dates = value
+		case "prize", "prize pool":
+			prize = value
+		case "location":
+			e.Location = value
+		}
+	})
+	e.Prize = parseMoney(prize)
+
+	// Stage headings, group tables and brackets come back in document
+	// order, so each table belongs to the last heading seen before it.
+	// Headings without tables (e.g. "Prize Distribution") are dropped.
+	var heading string
+	doc.Find(".wf-label.mod-large, table.mod-group, .bracket-container").Each(func(_ int, s *goquery.Selection) {
+		if s.HasClass("wf-label") {
+			heading = cleanText(s.Text())
+			return
+		}
+		if len(e.Stages) == 0 || e.Stages[len(e.Stages)-1].Name != heading {
+			e.Stages = append(e.Stages, models.EventStage{Name: heading})
+		}
+		stage := &e.Stages[len(e.Stages)-1]
+		if s.Is("table") {
+			stage.Groups = append(stage.Groups, parseGroupTable(s))
+		} else {
+			stage.Brackets = append(stage.Brackets, parseBracket(s))
+		}
+	})
+
+	doc.Find(".event-team").Each(func(_ int, s *goquery.Selection) {
+		a := s.Find("a.event-team-name")
+		team := models.EventTeam{
+			Name: cleanText(a.Text()),
+			Logo: utils.AbsoluteURL(s.Find("img.event-team-players-mask-team, img").First().AttrOr("src", "")),
+			Seed: cleanText(s.Find(".event-team-note").Text()),
+		}
+		team.TeamID, team.Slug = utils.EntityID(a.AttrOr("href", ""), "team")
+		e.Teams = append(e.Teams, team)
+	})
+
+	e.Prizes = parsePrizeTable(doc)
+	e.Raw = rawStrings("prize", prize)
+	return e, nil
+}
+
+// parseGroupTable reads a group standings table. The first header cell is
+// the group name; W, L and T columns are matched by header and the remaining
+// columns are the map and round differentials, in that order.
+func parseGroupTable(table *goquery.Selection) models.GroupStandings {
+	var g models.GroupStandings
+	var labels []string
+	table.Find("thead th, tr:first-child th").Each(func(i int, th *goquery.Selection) {
+		text := cleanText(th.Text())
+		if i == 0 {
+			g.Name = text
+		}
+		labels = append(labels, strings.ToUpper(text))
+	})
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() == 0 {
+			return
+		}
+		teamCell := cells.First()
+		a := teamCell.Find("a[href*='/team/']").First()
+		name := teamCell.Find(".event-group-team, .text-of").First()
+		if name.Length() == 0 {
+			name = teamCell
+		}
+		s := models.GroupStanding{Position: i + 1, Team: cleanText(name.Text())}
+		s.TeamID, _ = utils.EntityID(a.AttrOr("href", ""), "team")
+
+		var raw []string
+		diffs := 0
+		cells.Each(func(j int, td *goquery.Selection) {
+			if j == 0 || j >= len(labels) {
+				return
+			}
+			text := cleanText(td.Text())
+			switch labels[j] {
+			case "W":
+				s.Wins = utils.ParseInt(text)
+			case "L":
+				s.Losses = utils.ParseInt(text)
+			case "T":
+				s.Ties = utils.ParseInt(text)
+			default:
+				if text == "" {
+					return
+				}
+				// Differentials: maps first, then rounds
+				value := utils.ParseInt(strings.TrimPrefix(text, "+"))
+				switch diffs {
+				case 0:
+					s.MapDiff = value
+					raw = append(raw, "map_diff", text)
+				case 1:
+					s.RoundDiff = value
+					raw = append(raw, "round_diff", text)
+				}
+				diffs++
+			}
+		})
+		s.Raw = rawStrings(raw...)
+		g.Rows = append(g.Rows, s)
+	})
+	return g
+}
+
+// parseBracket reads a .bracket-container; mod-upper / mod-lower mark the
+// two halves of a double-elimination bracket.
+func parseBracket(container *goquery.Selection) models.Bracket {
+	var b models.Bracket
+	switch {
+	case container.HasClass("mod-upper"):
+		b.Name = "upper"
+	case container.HasClass("mod-lower"):
+		b.Name = "lower"
+	}
+
+	container.Find(".bracket-col").Each(func(_ int, col *goquery.Selection) {
+		round := models.BracketRound{Name: cleanText(col.Find(".bracket-col-label").Text())}
+		col.Find(".bracket-item").Each(func(_ int, item *goquery.Selection) {
+			var m models.BracketMatch
+			if href := item.Find("a").AttrOr("href", item.Closest("a").AttrOr("href", "")); href != "" {
+				m.MatchID, _ = utils.EntityID(href, "")
+				if m.MatchID != 0 {
+					m.MatchPage = utils.AbsoluteURL(href)
+				}
+			}
+			item.Find(".bracket-item-team").Each(func(i int, t *goquery.Selection) {
+				slot := models.BracketSlot{
+					Name:   cleanText(t.Find(".bracket-item-team-name").Text()),
+					Score:  utils.ParseInt(t.Find(".bracket-item-team-score").Text()),
+					Winner: t.HasClass("mod-winner"),
+				}
+				switch i {
+				case 0:
+					m.Team1 = slot
+				case 1:
+					m.Team2 = slot
+				}
+			})
+			round.Matches = append(round.Matches, m)
+		})
+		b.Rounds = append(b.Rounds, round)
+	})
+	return b
+}
+
+// parsePrizeTable reads the table following the "Prize Distribution"
+// heading. Each row has the place, the prize and the team.
+func parsePrizeTable(doc *goquery.Document) []models.PrizePlacement {
+	var prizes []models.PrizePlacement
+	doc.Find(".wf-label").Each(func(_ int, label *goquery.Selection) {
+		if !strings.EqualFold(cleanText(label.Text()), "Prize Distribution") {
+			return
+		}
+		label.NextAllFiltered(".wf-card, table").First().Find("tr").Each(func(_ int, row *goquery.Selection) {
+			cells := row.Find("td")
+			if cells.Length() < 2 {
+				return
+			}
+			place := cleanText(cells.Eq(0).Text())
+			prize := cleanText(cells.Eq(1).Text())
+			a := row.Find("a[href*='/team/']").First()
+			team := cleanText(row.Find(".standing-item-team-name").Text())
+			if team == "" {
+				team = cleanText(a.Text())
+			}
+			p := models.PrizePlacement{
+				Place: place,
+				Prize: parseMoney(prize),
+				Team:  team,
+				Raw:   rawStrings("prize", prize),
+			}
+			p.TeamID, _ = utils.EntityID(a.AttrOr("href", ""), "team")
+			prizes = append(prizes, p)
+		})
+	})
+	return prizes
+}
+
+//
+// VlrEventMatches godoc
+// @Summary      Get the matches of an event
+// @Description  Returns the matches of an event in schedule order, as the same rows as /vlr/match: upcoming and live matches by default (live ones with eta LIVE), completed matches with results=true
+// @Tags         events
+// @Produce      json
+// @Param        id       path      int   true   "vlr.gg event ID"
+// @Param        results  query     bool  false  "Return completed matches (models.MatchResult rows) instead of upcoming and live ones"
+// @Success      200      {object}  models.SegmentsResponse[models.ScheduledMatch]  "Upcoming and live matches. With results=true the segments are models.MatchResult rows instead (models.SegmentsResponse[models.MatchResult])"
+// @Failure      400      {object}  models.ErrorResponse
+// @Failure      404      {object}  models.ErrorResponse
+// @Failure      500      {object}  models.ErrorResponse
+// @Router       /vlr/event/{id}/matches [get]
+//
+func VlrEventMatches(c *fiber.Ctx) error {
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || eventID <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid event id"})
+	}
+
+	url := fmt.Sprintf("%s/event/matches/%d/?series_id=all&group=all", utils.BaseURL(), eventID)
+	resp, err := fetch.Get(c.UserContext(), url)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch event matches"})
+	}
+	if resp.StatusCode == fiber.StatusNotFound {
+		return c.Status(404).JSON(fiber.Map{"error": "Event not found"})
+	}
+	schedule, results, err := ParseEventMatches(bytes.NewReader(resp.Body))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+	}
+
+	if c.QueryBool("results") {
+		return c.JSON(models.NewSegments(resp.StatusCode, results))
+	}
+	return c.JSON(models.NewSegments(resp.StatusCode, schedule))
+}
+
+// ParseEventMatches extracts the match list of a vlr.gg event matches page,
+// split like the global /matches pages: upcoming and live matches as
+// schedule rows, completed ones as results. Matches are grouped into cards
+// under date headings.
+func ParseEventMatches(r io.Reader) ([]models.ScheduledMatch, []models.MatchResult, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := doc.Find(".event-header")
+	eventName := cleanText(header.Find("h1.wf-title").Text())
+	eventIcon := utils.AbsoluteURL(header.Find(".event-header-thumb img").AttrOr("src", ""))
+
+	now := clock()
+	schedule := []models.ScheduledMatch{}
+	results := []models.MatchResult{}
+	doc.Find("a.match-item").Each(func(_ int, s *goquery.Selection) {
+		href := s.AttrOr("href", "")
+		matchID, matchSlug := utils.EntityID(href, "")
+		matchTime := cleanText(s.Find(".match-item-time").Text())
+
+		teams := s.Find(".match-item-vs-team")
+		team1 := cleanText(teams.Eq(0).Find(".text-of").Text())
+		team2 := cleanText(teams.Eq(1).Find(".text-of").Text())
+		flag1 := teams.Eq(0).Find(".flag").AttrOr("class", "")
+		flag2 := teams.Eq(1).Find(".flag").AttrOr("class", "")
+
+		// The event page lists the stage ("Playoffs") around the series
+		// ("Upper Final"); the global pages show them as "Playoffs: Upper
+		// Final".
+		round := cleanText(s.Find(".match-item-event-series").Text())
+		stageSel := s.Find(".match-item-event").Clone()
+		stageSel.Find(".match-item-event-series").Remove()
+		if stage := cleanText(stageSel.Text()); stage != "" && round != "" {
+			round = stage + ": " + round
+		} else if stage != "" {
+			round = stage
+		}
+
+		eta := cleanText(s.Find(".ml-eta").Text())
+		status := strings.ToLower(cleanText(s.Find(".ml-status").Text()))
+		if status == "completed" {
+			score1 := cleanText(teams.Eq(0).Find(".match-item-vs-team-score").Text())
+			score2 := cleanText(teams.Eq(1).Find(".match-item-vs-team-score").Text())
+			results = append(results, models.MatchResult{
+				MatchID:        matchID,
+				MatchSlug:      matchSlug,
+				Team1:          team1,
+				Team2:          team2,
+				Score1:         utils.ParseInt(score1),
+				Score2:         utils.ParseInt(score2),
+				Country1:       country(flag1, ""),
+				Country2:       country(flag2, ""),
+				TimeCompleted:  matchTime,
+				CompletedAt:    parseRelativeTime(eta, now),
+				RoundInfo:      round,
+				TournamentName: eventName,
+				MatchPage:      utils.AbsoluteURL(href),
+				TournamentIcon: eventIcon,
+				PageNumber:     1,
+				Raw: rawStrings(
+					"score1", score1,
+					"score2", score2,
+					"completed_at", eta,
+					"country1", flag1,
+					"country2", flag2,
+				),
+			})
+			return
+		}
+
+		if status == "live" {
+			eta = "LIVE"
+		}
+		schedule = append(schedule, models.ScheduledMatch{
+			MatchID:   matchID,
+			MatchSlug: matchSlug,
+			MatchTime: matchTime,
+			Team1:     team1,
+			Team2:     team2,
+			Country1:  country(flag1, ""),
+			Country2:  country(flag2, ""),
+			Event:     eventName,
+			Series:    round,
+			ETA:       eta,
+			StartsAt:  parseRelativeTime(eta, now),
+			MatchPage: utils.AbsoluteURL(href),
+			Raw: rawStrings(
+				"starts_at", eta,
+				"country1", flag1,
+				"country2", flag2,
+			),
+		})
+	})
+	return schedule, results, nil
+}
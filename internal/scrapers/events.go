@@ -1,12 +1,19 @@
 package scrapers
 
 import (
-	"net/http"
+	"bytes"
+	"context"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gofiber/fiber/v2"
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/refresh"
 	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
 )
 
 //
@@ -17,38 +24,80 @@ import (
 // @Produce      json
 // @Param        upcoming   query     bool  false  "If true, return only upcoming events"
 // @Param        completed  query     bool  false  "If true, return only completed events"
-// @Success      200  {object}  map[string]interface{}
-// @Failure      500  {object}  map[string]string
+// @Success      200  {object}  models.SegmentsResponse[models.Event]
+// @Failure      500  {object}  models.ErrorResponse
 // @Router       /vlr/events [get]
 //
 func VlrEvents(c *fiber.Ctx) error {
-	url := "https://www.vlr.gg/events"
-	req, _ := http.NewRequest("GET", url, nil)
-	for k, v := range utils.Headers {
-		req.Header.Set(k, v)
+	return eventsScraper.serve(c)
+}
+
+var eventsScraper = &storedScraper[models.SegmentsResponse[models.Event]]{
+	name:        "events",
+	route:       "/events",
+	description: "Upcoming and completed events",
+	interval:    refresh.Interval("events", 30*time.Minute),
+	policy:      cache.PolicyFromEnv("events", cache.Policy{TTL: 10 * time.Minute, Stale: 30 * time.Minute}),
+	variants:    []string{"", "completed=false", "upcoming=false"},
+	key:         eventsKey,
+	scrape:      scrapeEvents,
+	stamp:       stampSegments[models.Event],
+}
+
+func init() {
+	RegisterScraper(eventsScraper)
+}
+
+// eventsColumns reads which columns of the events page a request wants.
+func eventsColumns(q map[string]string) (showUpcoming, showCompleted bool) {
+	showUpcoming = q["upcoming"] != "false"
+	showCompleted = q["completed"] != "false"
+
+	// If both are explicitly false, show both (default)
+	if !showUpcoming && !showCompleted {
+		showUpcoming = true
+		showCompleted = true
+	}
+	return showUpcoming, showCompleted
+}
+
+func eventsKey(q map[string]string) (string, error) {
+	showUpcoming, showCompleted := eventsColumns(q)
+	switch {
+	case !showCompleted:
+		return "completed=false", nil
+	case !showUpcoming:
+		return "upcoming=false", nil
 	}
-	resp, err := http.DefaultClient.Do(req)
+	return "", nil
+}
+
+func scrapeEvents(ctx context.Context, q map[string]string) (models.SegmentsResponse[models.Event], error) {
+	showUpcoming, showCompleted := eventsColumns(q)
+
+	url := utils.BaseURL() + "/events"
+	resp, err := fetch.Get(ctx, url)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch events"})
+		return models.SegmentsResponse[models.Event]{}, &httpError{500, "Failed to fetch events", err}
 	}
-	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	events, err := ParseEvents(bytes.NewReader(resp.Body), showUpcoming, showCompleted)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+		return models.SegmentsResponse[models.Event]{}, &httpError{500, "Failed to parse HTML", err}
 	}
 
-	// Query params
-	showUpcoming := c.Query("upcoming") != "false"
-	showCompleted := c.Query("completed") != "false"
+	return models.NewSegments(resp.StatusCode, events), nil
+}
 
-	// If both are explicitly false, show both (default)
-	if !showUpcoming && !showCompleted {
-		showUpcoming = true
-		showCompleted = true
+// ParseEvents extracts event cards from a vlr.gg /events page, limited to the
+// upcoming and/or completed columns.
+func ParseEvents(r io.Reader, showUpcoming, showCompleted bool) ([]models.Event, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
 	}
 
-	events := []map[string]string{}
+	events := []models.Event{}
 
 	// Helper to parse event cards
 	parseEvents := func(sel *goquery.Selection) {
@@ -57,33 +106,26 @@ func VlrEvents(c *fiber.Ctx) error {
 			status := strings.TrimSpace(s.Find(".event-item-desc-item-status").Text())
 			prize := strings.TrimSpace(s.Find(".event-item-desc-item.mod-prize").Clone().Children().Remove().End().Text())
 			dates := strings.TrimSpace(s.Find(".event-item-desc-item.mod-dates").Clone().Children().Remove().End().Text())
-			region := ""
-			flag := s.Find(".event-item-desc-item.mod-location .flag")
-			if flag.Length() > 0 {
-				class, _ := flag.Attr("class")
-				region = strings.TrimSpace(strings.ReplaceAll(class, "flag mod-", ""))
-			}
+			flag := s.Find(".event-item-desc-item.mod-location .flag").AttrOr("class", "")
 			thumb := ""
 			img := s.Find(".event-item-thumb img")
 			if img.Length() > 0 {
 				src, _ := img.Attr("src")
-				if strings.HasPrefix(src, "//") {
-					thumb = "https:" + src
-				} else if strings.HasPrefix(src, "/") {
-					thumb = "https://www.vlr.gg" + src
-				} else {
-					thumb = src
-				}
+				thumb = utils.AbsoluteURL(src)
 			}
 			urlPath, _ := s.Attr("href")
-			events = append(events, map[string]string{
-				"title":    title,
-				"status":   status,
-				"prize":    prize,
-				"dates":    dates,
-				"region":   region,
-				"thumb":    thumb,
-				"url_path": "https://www.vlr.gg" + urlPath,
+			eventID, slug := utils.EntityID(urlPath, "event")
+			events = append(events, models.Event{
+				EventID: eventID,
+				Slug:    slug,
+				Title:   title,
+				Status:  status,
+				Prize:   parseMoney(prize),
+				Dates:   dates,
+				Country: country(flag, ""),
+				Thumb:   thumb,
+				URLPath: utils.AbsoluteURL(urlPath),
+				Raw:     rawStrings("prize", prize, "country", flag),
 			})
 		})
 	}
@@ -109,5 +151,5 @@ func VlrEvents(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(fiber.Map{"data": fiber.Map{"status": resp.StatusCode, "segments": events}})
+	return events, nil
 }
@@ -0,0 +1,62 @@
+package scrapers
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fetchedAtField opens the JSON field models.Freshness.FetchedAt.
+var fetchedAtField = []byte(`"fetched_at":"`)
+
+// FreshnessAge fills in the age of responses that carry fetched_at as they
+// are sent. Handlers leave the age out so the response cache, which sits
+// inside this middleware, stores and tags bodies that do not change while
+// they are cached; register it before cache.Middleware.
+func FreshnessAge() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+		resp := c.Response()
+		if resp.StatusCode() != fiber.StatusOK || resp.IsBodyStream() ||
+			!strings.HasPrefix(string(resp.Header.ContentType()), fiber.MIMEApplicationJSON) {
+			return nil
+		}
+		if body, ok := stampAge(resp.Body(), clock()); ok {
+			resp.SetBodyRaw(body)
+		}
+		return nil
+	}
+}
+
+// stampAge returns body with `"age":N` inserted after its fetched_at field,
+// N being the whole seconds between fetched_at and now. Freshness is the last
+// field of every envelope it is embedded in, so the last fetched_at is the
+// envelope's even if scraped data happened to contain one.
+func stampAge(body []byte, now time.Time) ([]byte, bool) {
+	i := bytes.LastIndex(body, fetchedAtField)
+	if i < 0 {
+		return nil, false
+	}
+	start := i + len(fetchedAtField)
+	end := bytes.IndexByte(body[start:], '"')
+	if end < 0 {
+		return nil, false
+	}
+	end += start
+	fetchedAt, err := time.Parse(time.RFC3339Nano, string(body[start:end]))
+	if err != nil {
+		return nil, false
+	}
+	age := max(int64(now.Sub(fetchedAt)/time.Second), 0)
+
+	out := make([]byte, 0, len(body)+32)
+	out = append(out, body[:end+1]...)
+	out = append(out, `,"age":`...)
+	out = strconv.AppendInt(out, age, 10)
+	return append(out, body[end+1:]...), true
+}
@@ -0,0 +1,93 @@
+package scrapers
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"vlrggapi/internal/cache"
+	"vlrggapi/pkg/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestStampAge(t *testing.T) {
+	now := time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"segments", `{"data":{"status":200,"segments":[],"fetched_at":"2026-10-17T17:58:30Z"}}`,
+			`{"data":{"status":200,"segments":[],"fetched_at":"2026-10-17T17:58:30Z","age":90}}`},
+		{"fractional seconds", `{"fetched_at":"2026-10-17T17:59:59.75Z"}`, `{"fetched_at":"2026-10-17T17:59:59.75Z","age":0}`},
+		{"offset", `{"fetched_at":"2026-10-17T19:00:00+02:00"}`, `{"fetched_at":"2026-10-17T19:00:00+02:00","age":3600}`},
+		{"clock skew", `{"fetched_at":"2026-10-17T18:00:05Z"}`, `{"fetched_at":"2026-10-17T18:00:05Z","age":0}`},
+		{"last field wins", `{"data":{"raw":{"fetched_at":"2020-01-01T00:00:00Z"}},"fetched_at":"2026-10-17T17:59:00Z"}`,
+			`{"data":{"raw":{"fetched_at":"2020-01-01T00:00:00Z"}},"fetched_at":"2026-10-17T17:59:00Z","age":60}`},
+		{"no freshness", `{"data":{"status":200,"segments":[]}}`, ""},
+		{"not a time", `{"fetched_at":"yesterday"}`, ""},
+		{"truncated", `{"fetched_at":"2026-10-17T17:59:00Z`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := stampAge([]byte(tt.body), now)
+			if ok != (tt.want != "") || string(got) != tt.want {
+				t.Errorf("stampAge(%s) = %s, %v; want %q", tt.body, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+// TestFreshnessAgeThroughCache checks that a response held by the response
+// cache reports the age of its data as of each request, not as of when it
+// was cached.
+func TestFreshnessAgeThroughCache(t *testing.T) {
+	defer func(saved func() time.Time) { clock = saved }(clock)
+	now := fixedNow
+	clock = func() time.Time { return now }
+
+	fetchedAt := fixedNow.Add(-10 * time.Second)
+	calls := 0
+	app := fiber.New()
+	app.Use(FreshnessAge())
+	app.Use(cache.Middleware(cache.NewMemory(0, 0, 0), cache.Policy{TTL: time.Hour}, cache.NewStats(), nil))
+	app.Get("/vlr/news", func(c *fiber.Ctx) error {
+		calls++
+		resp := models.NewSegments(200, []models.NewsArticle{})
+		resp.Data.Freshness = models.NewFreshness(fetchedAt)
+		return c.JSON(resp)
+	})
+
+	var etags []string
+	for _, tt := range []struct {
+		advance time.Duration
+		xcache  string
+		want    string
+	}{
+		{0, cache.OutcomeMiss, `{"data":{"status":200,"segments":[],"fetched_at":"2026-10-17T17:59:50Z","age":10}}`},
+		{time.Minute, cache.OutcomeHit, `{"data":{"status":200,"segments":[],"fetched_at":"2026-10-17T17:59:50Z","age":70}}`},
+	} {
+		now = now.Add(tt.advance)
+		resp, err := app.Test(httptest.NewRequest("GET", "/vlr/news", nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if got := resp.Header.Get("X-Cache"); got != tt.xcache {
+			t.Errorf("X-Cache = %q, want %q", got, tt.xcache)
+		}
+		if string(body) != tt.want {
+			t.Errorf("%s body = %s\nwant %s", tt.xcache, body, tt.want)
+		}
+		etags = append(etags, resp.Header.Get(fiber.HeaderETag))
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+	if etags[0] != etags[1] {
+		t.Errorf("ETag changed with the age: %q then %q", etags[0], etags[1])
+	}
+}
@@ -1,9 +1,13 @@
 package scrapers
 
 import (
-	"net/http"
+	"errors"
 	"time"
 
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -13,27 +17,32 @@ import (
 // @Description  Returns health status of the API and upstream sources
 // @Tags         health
 // @Produce      json
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  map[string]models.SiteHealth
 // @Router       /vlr/health [get]
 //
 func Health(c *fiber.Ctx) error {
-	sites := []string{"https://vlrggapi.vercel.app", "https://vlr.gg"}
-	results := make(map[string]map[string]interface{})
+	sites := []string{"https://vlrggapi.vercel.app", utils.BaseURL()}
+	results := make(map[string]models.SiteHealth)
 	for _, site := range sites {
-		client := http.Client{Timeout: 5 * time.Second}
-		resp, err := client.Get(site)
+		resp, err := fetch.Do(c.UserContext(), fetch.Request{
+			URL:         site,
+			MaxAttempts: 1,
+			Timeout:     5 * time.Second,
+		})
 		status := "Unhealthy"
 		statusCode := 0
+		var statusErr *fetch.StatusError
 		if err == nil {
 			if resp.StatusCode == 200 {
 				status = "Healthy"
 			}
 			statusCode = resp.StatusCode
-			resp.Body.Close()
+		} else if errors.As(err, &statusErr) {
+			statusCode = statusErr.StatusCode
 		}
-		results[site] = map[string]interface{}{
-			"status":      status,
-			"status_code": statusCode,
+		results[site] = models.SiteHealth{
+			Status:     status,
+			StatusCode: statusCode,
 		}
 	}
 	return c.JSON(results)
@@ -0,0 +1,148 @@
+package scrapers
+
+import (
+	"encoding/json"
+	"time"
+
+	"vlrggapi/internal/live"
+	"vlrggapi/pkg/models"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// socketWriteTimeout bounds a single write to a WebSocket client. A
+	// client that cannot take a message in time is disconnected.
+	socketWriteTimeout = 10 * time.Second
+	// socketReadLimit caps the size of a client message.
+	socketReadLimit = 4096
+)
+
+//
+// VlrLiveSocket godoc
+// @Summary      Subscribe to live match changes over WebSocket
+// @Description  WebSocket endpoint for following specific live matches. Send {"action":"subscribe"} or {"action":"unsubscribe"} with any of match_id, event_id and team_id. The server answers with a models.LiveSocketReply ("subscribed", "unsubscribed" or "error"), pushes models.LiveEvent messages ("snapshot", "match_started", "round_won", "map_ended", "match_ended") for subscribed matches and sends a "heartbeat" every 15 seconds. Clients that fall behind are closed with code 1013.
+// @Tags         matches
+// @Param        Upgrade  header  string  true  "websocket"
+// @Success      101
+// @Failure      426  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Router       /vlr/live/ws [get]
+//
+func VlrLiveSocket(c *fiber.Ctx) error {
+	if live.Default() == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Live stream is not available"})
+	}
+	if !websocket.IsWebSocketUpgrade(c) {
+		return c.Status(fiber.StatusUpgradeRequired).JSON(fiber.Map{"error": "Expected a WebSocket upgrade"})
+	}
+	return liveSocketUpgrade(c)
+}
+
+var liveSocketUpgrade = websocket.New(serveLiveSocket)
+
+// serveLiveSocket runs one connection. All writes happen here; client
+// messages arrive from readLiveSocket over a channel. The poller never waits
+// on the connection: if its buffer fills up because writes are slow, the
+// subscription is dropped and the client is disconnected.
+func serveLiveSocket(conn *websocket.Conn) {
+	poller := live.Default()
+	events, cancel := poller.Subscribe()
+	defer cancel()
+
+	done := make(chan struct{})
+	defer close(done)
+	requests := make(chan models.LiveSocketRequest)
+	go readLiveSocket(conn, requests, done)
+
+	var filter live.Filter
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		var msg any
+		select {
+		case req, ok := <-requests:
+			if !ok {
+				return
+			}
+			msg = liveSocketReply(&filter, req, poller)
+		case ev, ok := <-events:
+			if !ok {
+				deadline := time.Now().Add(socketWriteTimeout)
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client too slow"), deadline)
+				return
+			}
+			if ev, ok = filter.Apply(ev); !ok {
+				continue
+			}
+			msg = ev
+		case <-heartbeat.C:
+			msg = models.LiveSocketReply{Type: "heartbeat", At: time.Now().UTC()}
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(socketWriteTimeout))
+		if err := conn.WriteJSON(msg); err != nil {
+			return
+		}
+	}
+}
+
+// readLiveSocket forwards client messages until the connection fails or done
+// is closed. Messages that are not valid JSON are passed on with an empty
+// action so they get an error reply.
+func readLiveSocket(conn *websocket.Conn, requests chan<- models.LiveSocketRequest, done <-chan struct{}) {
+	defer close(requests)
+	conn.SetReadLimit(socketReadLimit)
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req models.LiveSocketRequest
+		if json.Unmarshal(data, &req) != nil {
+			req = models.LiveSocketRequest{}
+		}
+		select {
+		case requests <- req:
+		case <-done:
+			return
+		}
+	}
+}
+
+// liveSocketReply applies a client request to filter and builds the reply.
+func liveSocketReply(filter *live.Filter, req models.LiveSocketRequest, poller *live.Poller) models.LiveSocketReply {
+	now := time.Now().UTC()
+	if req.Action != "subscribe" && req.Action != "unsubscribe" {
+		return models.LiveSocketReply{Type: "error", Error: `action must be "subscribe" or "unsubscribe"`, At: now}
+	}
+	if req.MatchID <= 0 && req.EventID <= 0 && req.TeamID <= 0 {
+		return models.LiveSocketReply{Type: "error", Error: "match_id, event_id or team_id is required", At: now}
+	}
+
+	reply := models.LiveSocketReply{Type: "unsubscribed", At: now}
+	if req.Action == "subscribe" {
+		filter.Add(req.MatchID, req.EventID, req.TeamID)
+		added := live.Filter{}
+		added.Add(req.MatchID, req.EventID, req.TeamID)
+		if matches, ok := poller.Snapshot(); ok {
+			reply.Matches = added.Matches(matches)
+		}
+		reply.Type = "subscribed"
+	} else {
+		filter.Remove(req.MatchID, req.EventID, req.TeamID)
+	}
+	reply.Subscriptions = &models.LiveSubscriptions{
+		MatchIDs: nonNil(filter.MatchIDs),
+		EventIDs: nonNil(filter.EventIDs),
+		TeamIDs:  nonNil(filter.TeamIDs),
+	}
+	return reply
+}
+
+// nonNil returns a copy of ids that serialises as [] rather than null.
+func nonNil(ids []int) []int {
+	return append([]int{}, ids...)
+}
@@ -0,0 +1,72 @@
+package scrapers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"vlrggapi/internal/live"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// streamHeartbeat is how often a comment line is written to idle streams so
+// proxies keep the connection open and closed clients are noticed.
+const streamHeartbeat = 15 * time.Second
+
+//
+// VlrLiveStream godoc
+// @Summary      Stream live match score changes
+// @Description  Server-Sent Events feed of live match changes. A "snapshot" event with every live match is sent first, followed by "match_started", "round_won", "map_ended" and "match_ended" events as the background poller sees them. Each event's data is a models.LiveEvent JSON object.
+// @Tags         matches
+// @Produce      text/event-stream
+// @Success      200  {object}  models.LiveEvent
+// @Failure      503  {object}  models.ErrorResponse
+// @Router       /vlr/live/stream [get]
+//
+func VlrLiveStream(c *fiber.Ctx) error {
+	poller := live.Default()
+	if poller == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Live stream is not available"})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	events, cancel := poller.Subscribe()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		// Open the stream right away so clients see the headers before the
+		// first poll completes.
+		fmt.Fprint(w, ": connected\n\n")
+		if w.Flush() != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(ev)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, data)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			if w.Flush() != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,339 @@
+package scrapers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gofiber/fiber/v2"
+)
+
+//
+// VlrMatchDetail godoc
+// @Summary      Get a single match
+// @Description  Returns series metadata, the map veto, per-map scores with attack/defense splits and per-player scoreboards for each map and the whole series
+// @Tags         matches
+// @Produce      json
+// @Param        id       path      int     true   "vlr.gg match ID"
+// @Param        include  query     string  false  "Comma-separated extra tabs to fetch: economy, performance"
+// @Success      200  {object}  models.SegmentsResponse[models.MatchDetail]
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /vlr/match/{id} [get]
+//
+func VlrMatchDetail(c *fiber.Ctx) error {
+	matchID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || matchID <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid match id"})
+	}
+	include, err := parseInclude(c.Query("include"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": userMessage(err)})
+	}
+
+	resp, err := fetchMatchPage(c.UserContext(), matchID, "")
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch match"})
+	}
+	if resp.StatusCode == fiber.StatusNotFound {
+		return c.Status(404).JSON(fiber.Map{"error": "Match not found"})
+	}
+
+	detail, err := ParseMatchDetail(bytes.NewReader(resp.Body))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+	}
+	if detail.MatchID == 0 {
+		detail.MatchID = matchID
+	}
+
+	// The tabs are fetched after the match page was found, so any other
+	// status than 200 (a 403 from vlr.gg, a 404 for a tab it no longer
+	// serves) is a failure rather than a missing match; its body would parse
+	// as an empty tab.
+	if include["economy"] {
+		econResp, err := fetchMatchPage(c.UserContext(), matchID, "tab=economy")
+		if err != nil || econResp.StatusCode != fiber.StatusOK {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch match economy"})
+		}
+		economy, err := ParseMatchEconomy(bytes.NewReader(econResp.Body))
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+		}
+		for i := range economy {
+			for j := range detail.Maps {
+				if detail.Maps[j].GameID == economy[i].GameID {
+					detail.Maps[j].Economy = &economy[i]
+				}
+			}
+		}
+	}
+
+	if include["performance"] {
+		perfResp, err := fetchMatchPage(c.UserContext(), matchID, "tab=performance")
+		if err != nil || perfResp.StatusCode != fiber.StatusOK {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch match performance"})
+		}
+		performance, err := ParseMatchPerformance(bytes.NewReader(perfResp.Body))
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+		}
+		for i := range performance {
+			for j := range detail.Maps {
+				if detail.Maps[j].GameID == performance[i].GameID {
+					detail.Maps[j].Performance = &performance[i]
+				}
+			}
+		}
+	}
+
+	return c.JSON(models.NewSegments(resp.StatusCode, []models.MatchDetail{detail}))
+}
+
+// matchIncludes are the optional match page tabs that can be requested with
+// ?include=.
+var matchIncludes = []string{"economy", "performance"}
+
+// parseInclude validates a comma-separated include list.
+func parseInclude(value string) (map[string]bool, error) {
+	include := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		if !slices.Contains(matchIncludes, part) {
+			return nil, fmt.Errorf("invalid include %q, expected one of: %s", part, strings.Join(matchIncludes, ", "))
+		}
+		include[part] = true
+	}
+	return include, nil
+}
+
+// fetchMatchPage downloads a match page, optionally with a tab query such
+// as "tab=economy". vlr.gg redirects /{id} to the slugged URL.
+func fetchMatchPage(ctx context.Context, matchID int, query string) (*fetch.Response, error) {
+	url := fmt.Sprintf("%s/%d", utils.BaseURL(), matchID)
+	if query != "" {
+		url += "/?" + query
+	}
+	return fetch.Get(ctx, url)
+}
+
+// ParseMatchDetail extracts the header, veto, map scores and scoreboards from
+// a vlr.gg match page.
+func ParseMatchDetail(r io.Reader) (models.MatchDetail, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return models.MatchDetail{}, err
+	}
+	return parseMatchDetail(doc), nil
+}
+
+func parseMatchDetail(doc *goquery.Document) models.MatchDetail {
+	var d models.MatchDetail
+
+	canonical := doc.Find("link[rel='canonical']").AttrOr("href", "")
+	if canonical == "" {
+		canonical = doc.Find("meta[property='og:url']").AttrOr("content", "")
+	}
+	d.MatchID, d.MatchSlug = utils.EntityID(canonical, "")
+	d.URL = utils.AbsoluteURL(canonical)
+
+	// Event and series
+	eventLink := doc.Find(".match-header-super a.match-header-event").First()
+	eventHref := eventLink.AttrOr("href", "")
+	d.Event.EventID, _ = utils.EntityID(eventHref, "event")
+	d.Event.URL = utils.AbsoluteURL(eventHref)
+	d.Event.Name = cleanText(eventLink.Find("div > div").First().Text())
+	d.Event.Series = cleanText(eventLink.Find(".match-header-event-series").Text())
+
+	// Date and patch
+	dateRaw := doc.Find(".match-header-date .moment-tz-convert").First().AttrOr("data-utc-ts", "")
+	d.Date = parseUTCTimestamp(dateRaw)
+	doc.Find(".match-header-date div").Each(func(_ int, s *goquery.Selection) {
+		if text := cleanText(s.Text()); strings.HasPrefix(text, "Patch") {
+			d.Patch = text
+		}
+	})
+
+	// Status and format, e.g. "final" / "Bo3"
+	notes := doc.Find(".match-header-vs-note")
+	d.Status = cleanText(notes.First().Text())
+	bestOf := cleanText(notes.Last().Text())
+	d.BestOf = utils.ParseInt(strings.TrimPrefix(strings.ToLower(bestOf), "bo"))
+
+	// Teams and series score
+	var scores []string
+	doc.Find(".match-header-vs-score .js-spoiler span").Each(func(_ int, s *goquery.Selection) {
+		if !s.HasClass("match-header-vs-score-colon") {
+			scores = append(scores, cleanText(s.Text()))
+		}
+	})
+	doc.Find("a.match-header-link").Each(func(i int, s *goquery.Selection) {
+		if i > 1 {
+			return
+		}
+		team := models.MatchTeam{
+			Name: cleanText(s.Find(".wf-title-med").Text()),
+			Logo: utils.AbsoluteURL(s.Find("img").AttrOr("src", "")),
+		}
+		team.TeamID, team.Slug = utils.EntityID(s.AttrOr("href", ""), "team")
+		if i < len(scores) {
+			team.Score = utils.ParseInt(scores[i])
+		}
+		d.Teams = append(d.Teams, team)
+	})
+	if len(d.Teams) == 2 && d.Teams[0].Score != nil && d.Teams[1].Score != nil && d.Status == "final" {
+		d.Teams[0].Winner = *d.Teams[0].Score > *d.Teams[1].Score
+		d.Teams[1].Winner = *d.Teams[1].Score > *d.Teams[0].Score
+	}
+
+	vetoRaw := cleanText(doc.Find(".match-header-note").Text())
+	d.Vetoes = parseVetoes(vetoRaw)
+
+	// Per-map blocks; the "all" block holds the series scoreboard
+	doc.Find(".vm-stats-game").Each(func(_ int, game *goquery.Selection) {
+		gameID := game.AttrOr("data-game-id", "")
+		players := parseScoreboard(game)
+		if gameID == "all" {
+			d.SeriesPlayers = players
+			return
+		}
+		id, err := strconv.Atoi(gameID)
+		if err != nil {
+			return
+		}
+		m := parseMapHeader(game.Find(".vm-stats-game-header"))
+		m.GameID = id
+		m.Players = players
+		for _, v := range d.Vetoes {
+			if v.Action == "pick" && strings.EqualFold(v.Map, m.Map) {
+				m.PickedBy = v.Team
+			}
+		}
+		d.Maps = append(d.Maps, m)
+	})
+
+	d.Raw = rawStrings(
+		"date", dateRaw,
+		"best_of", bestOf,
+		"vetoes", vetoRaw,
+	)
+	return d
+}
+
+// parseVetoes splits a header note such as
+// "PRX ban Ascent; FNC ban Bind; PRX pick Lotus; ...; Haven remains".
+func parseVetoes(note string) []models.MapVeto {
+	var vetoes []models.MapVeto
+	for _, step := range strings.Split(note, ";") {
+		fields := strings.Fields(step)
+		switch {
+		case len(fields) >= 3 && (fields[len(fields)-2] == "ban" || fields[len(fields)-2] == "pick"):
+			vetoes = append(vetoes, models.MapVeto{
+				Team:   strings.Join(fields[:len(fields)-2], " "),
+				Action: fields[len(fields)-2],
+				Map:    fields[len(fields)-1],
+			})
+		case len(fields) == 2 && fields[1] == "remains":
+			vetoes = append(vetoes, models.MapVeto{Action: "remains", Map: fields[0]})
+		}
+	}
+	for i := range vetoes {
+		vetoes[i].Order = i + 1
+	}
+	return vetoes
+}
+
+func parseMapHeader(header *goquery.Selection) models.MatchMap {
+	var m models.MatchMap
+	m.Map = firstField(header.Find(".map span").First().Text())
+	m.Duration = cleanText(header.Find(".map-duration").Text())
+
+	teams := header.Find(".team")
+	for i := 0; i < teams.Length() && i < 2; i++ {
+		t := teams.Eq(i)
+		score := models.MapScore{
+			Name:     cleanText(t.Find(".team-name").Text()),
+			Score:    utils.ParseInt(t.Find(".score").Text()),
+			Attack:   utils.ParseInt(t.Find("span.mod-t").First().Text()),
+			Defense:  utils.ParseInt(t.Find("span.mod-ct").First().Text()),
+			Overtime: utils.ParseInt(t.Find("span.mod-ot").First().Text()),
+		}
+		if t.Find(".score").HasClass("mod-win") {
+			m.Winner = i + 1
+		}
+		if i == 0 {
+			m.Team1 = score
+		} else {
+			m.Team2 = score
+		}
+	}
+	return m
+}
+
+// parseScoreboard reads the two overview tables (team 1 first) of a game
+// block. Stat columns are Rating, ACS, K, D, A, +/-, KAST, ADR, HS%, FK, FD,
+// +/-; only the both-sides value of each cell is used.
+func parseScoreboard(game *goquery.Selection) []models.PlayerGame {
+	var players []models.PlayerGame
+	game.Find("table.wf-table-inset.mod-overview").Each(func(t int, table *goquery.Selection) {
+		table.Find("tbody tr").Each(func(_ int, row *goquery.Selection) {
+			player := row.Find("td.mod-player")
+			href := player.Find("a").AttrOr("href", "")
+			p := models.PlayerGame{
+				Name:    cleanText(player.Find(".text-of").Text()),
+				TeamTag: cleanText(player.Find(".ge-text-light").Text()),
+				Team:    t + 1,
+			}
+			p.PlayerID, p.Slug = utils.EntityID(href, "player")
+			row.Find("td.mod-agents img").Each(func(_ int, img *goquery.Selection) {
+				p.Agents = append(p.Agents, img.AttrOr("title", img.AttrOr("alt", "")))
+			})
+
+			var cells []string
+			row.Find("td.mod-stat").Each(func(_ int, td *goquery.Selection) {
+				cells = append(cells, statCellText(td))
+			})
+			for len(cells) < 12 {
+				cells = append(cells, "")
+			}
+			p.Rating = utils.ParseFloat(cells[0])
+			p.ACS = utils.ParseFloat(cells[1])
+			p.Kills = utils.ParseInt(cells[2])
+			p.Deaths = utils.ParseInt(cells[3])
+			p.Assists = utils.ParseInt(cells[4])
+			p.KDDiff = utils.ParseInt(cells[5])
+			p.KAST = utils.ParseFloat(cells[6])
+			p.ADR = utils.ParseFloat(cells[7])
+			p.HSPercent = utils.ParseFloat(cells[8])
+			p.FirstKills = utils.ParseInt(cells[9])
+			p.FirstDeaths = utils.ParseInt(cells[10])
+			p.FKDiff = utils.ParseInt(cells[11])
+			players = append(players, p)
+		})
+	})
+	return players
+}
+
+// statCellText returns the both-sides value of a scoreboard cell, falling
+// back to the cell text for cells without side spans.
+func statCellText(td *goquery.Selection) string {
+	text := td.Find(".mod-both").First().Text()
+	if strings.TrimSpace(text) == "" {
+		text = td.Text()
+	}
+	text = strings.NewReplacer("−", "-", "/", "").Replace(text)
+	return firstField(text)
+}
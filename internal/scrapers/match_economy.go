@@ -0,0 +1,127 @@
+package scrapers
+
+import (
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ParseMatchEconomy extracts the per-map economy summaries and round-by-round
+// buys from the Economy tab of a vlr.gg match page (?tab=economy). The
+// series-wide "all" block is skipped.
+func ParseMatchEconomy(r io.Reader) ([]models.MapEconomy, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.MapEconomy
+	doc.Find(".vm-stats-game").Each(func(_ int, game *goquery.Selection) {
+		id, err := strconv.Atoi(game.AttrOr("data-game-id", ""))
+		if err != nil {
+			return
+		}
+		tables := game.Find("table.wf-table-inset.mod-econ")
+		if tables.Length() == 0 {
+			return
+		}
+		result = append(result, models.MapEconomy{
+			GameID: id,
+			Teams:  parseEconomySummary(tables.First()),
+			Rounds: parseEconomyRounds(tables.Slice(1, goquery.ToEnd)),
+		})
+	})
+	return result, nil
+}
+
+// parseEconomySummary reads the summary table. Its columns are the team,
+// pistols won, then eco, $, $$ and $$$ as "played (won)".
+func parseEconomySummary(table *goquery.Selection) []models.TeamEconomy {
+	var teams []models.TeamEconomy
+	table.Find("tr").Each(func(_ int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() < 6 {
+			return
+		}
+		cell := func(i int) string { return cleanText(cells.Eq(i).Text()) }
+		teams = append(teams, models.TeamEconomy{
+			Team:      cell(0),
+			PistolWon: utils.ParseInt(cell(1)),
+			Eco:       parseBuyStats(cell(2)),
+			SemiEco:   parseBuyStats(cell(3)),
+			SemiBuy:   parseBuyStats(cell(4)),
+			FullBuy:   parseBuyStats(cell(5)),
+		})
+	})
+	return teams
+}
+
+var playedWonRe = regexp.MustCompile(`(\d+)\s*\((\d+)\)`)
+
+func parseBuyStats(s string) models.BuyStats {
+	m := playedWonRe.FindStringSubmatch(s)
+	if m == nil {
+		return models.BuyStats{}
+	}
+	played, won := utils.ParseInt(m[1]), utils.ParseInt(m[2])
+	stats := models.BuyStats{Played: played, Won: won}
+	if *played > 0 {
+		rate := math.Round(float64(*won)/float64(*played)*1000) / 10
+		stats.WinRate = &rate
+	}
+	return stats
+}
+
+// parseEconomyRounds reads the round tables. Each round column holds the
+// round number, team 1's bank, one square per team whose text ("", "$",
+// "$$", "$$$") is the buy type and which carries mod-win for the winner,
+// then team 2's bank.
+func parseEconomyRounds(tables *goquery.Selection) []models.EconomyRound {
+	var rounds []models.EconomyRound
+	tables.Find("td").Each(func(_ int, col *goquery.Selection) {
+		number := utils.ParseInt(col.Find(".round-num").Text())
+		if number == nil {
+			return
+		}
+		round := models.EconomyRound{Number: *number}
+		banks := col.Find(".bank")
+		col.Find(".rnd-sq").Each(func(i int, sq *goquery.Selection) {
+			if i > 1 {
+				return
+			}
+			buy := models.RoundBuy{
+				Bank:    parseCredits(banks.Eq(i).Text()),
+				BuyType: buyType(sq.Text()),
+			}
+			if sq.HasClass("mod-win") {
+				round.Winner = i + 1
+			}
+			if i == 0 {
+				round.Team1 = buy
+			} else {
+				round.Team2 = buy
+			}
+		})
+		rounds = append(rounds, round)
+	})
+	return rounds
+}
+
+func buyType(s string) string {
+	switch strings.TrimSpace(s) {
+	case "$":
+		return "semi-eco"
+	case "$$":
+		return "semi-buy"
+	case "$$$":
+		return "full-buy"
+	}
+	return "eco"
+}
@@ -0,0 +1,158 @@
+package scrapers
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ParseMatchPerformance extracts the kill matrices and multikill/clutch
+// table of every map from the Performance tab of a vlr.gg match page
+// (?tab=performance). Players are identified by the IDs of their links on the
+// tab itself. The series-wide "all" block is skipped.
+func ParseMatchPerformance(r io.Reader) ([]models.MapPerformance, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.MapPerformance
+	doc.Find(".vm-stats-game").Each(func(_ int, game *goquery.Selection) {
+		id, err := strconv.Atoi(game.AttrOr("data-game-id", ""))
+		if err != nil {
+			return
+		}
+		if game.Find("table.mod-matrix, table.mod-adv-stats").Length() == 0 {
+			return
+		}
+		result = append(result, models.MapPerformance{
+			GameID:        id,
+			Kills:         parseKillMatrix(game.Find("table.mod-matrix.mod-normal").First()),
+			FirstKills:    parseKillMatrix(game.Find("table.mod-matrix.mod-fkfd").First()),
+			OperatorKills: parseKillMatrix(game.Find("table.mod-matrix.mod-op").First()),
+			Players:       parseAdvancedStats(game.Find("table.mod-adv-stats").First()),
+		})
+	})
+	return result, nil
+}
+
+// parseKillMatrix reads a matrix whose first row lists the team 2 players
+// and whose following rows start with a team 1 player. Each cell holds the
+// row player's kills on the column player, the reverse, and the difference.
+func parseKillMatrix(table *goquery.Selection) models.KillMatrix {
+	rows := table.Find("tr")
+	if rows.Length() < 2 {
+		return nil
+	}
+
+	type opponent struct {
+		id   int
+		name string
+	}
+	var opponents []opponent
+	rows.First().Find("td, th").Each(func(i int, td *goquery.Selection) {
+		if i > 0 {
+			opponents = append(opponents, opponent{matrixPlayerID(td), matrixPlayerName(td)})
+		}
+	})
+
+	matrix := make(models.KillMatrix)
+	rows.Slice(1, goquery.ToEnd).Each(func(_ int, row *goquery.Selection) {
+		cells := row.Find("td")
+		playerID := matrixPlayerID(cells.First())
+		if playerID == 0 {
+			return
+		}
+		player := matrixPlayerName(cells.First())
+		duels := make(map[int]models.Duel)
+		cells.Slice(1, goquery.ToEnd).Each(func(i int, td *goquery.Selection) {
+			if i >= len(opponents) || opponents[i].id == 0 {
+				return
+			}
+			sq := td.Find(".stats-sq")
+			duels[opponents[i].id] = models.Duel{
+				Player:   player,
+				Opponent: opponents[i].name,
+				Kills:    utils.ParseInt(sq.Eq(0).Text()),
+				Deaths:   utils.ParseInt(sq.Eq(1).Text()),
+			}
+		})
+		matrix[playerID] = duels
+	})
+	return matrix
+}
+
+// matrixPlayerID returns the ID of the player linked from a matrix or table
+// cell, or 0 when the cell has no player link.
+func matrixPlayerID(td *goquery.Selection) int {
+	id, _ := utils.EntityID(td.Find("a[href*='/player/']").First().AttrOr("href", ""), "player")
+	return id
+}
+
+// matrixPlayerName returns the player name of a matrix or table cell, which
+// is rendered as the name followed by a nested team tag.
+func matrixPlayerName(td *goquery.Selection) string {
+	name := td.Find(".team").First().Clone()
+	if name.Length() == 0 {
+		name = td.Clone()
+	}
+	name.Find(".team-tag").Remove()
+	return cleanText(name.Text())
+}
+
+// parseAdvancedStats reads the multikill/clutch table. Columns are matched
+// by their header (2K..5K, 1v1..1v5, ECON, PL, DE) after the player and
+// agent columns.
+func parseAdvancedStats(table *goquery.Selection) []models.PlayerPerformance {
+	rows := table.Find("tr")
+	if rows.Length() < 2 {
+		return nil
+	}
+
+	var labels []string
+	rows.First().Find("th, td").Each(func(_ int, th *goquery.Selection) {
+		labels = append(labels, strings.ToLower(cleanText(th.Text())))
+	})
+
+	var players []models.PlayerPerformance
+	rows.Slice(1, goquery.ToEnd).Each(func(_ int, row *goquery.Selection) {
+		cells := row.Find("td")
+		name := matrixPlayerName(cells.First())
+		if name == "" {
+			return
+		}
+		p := models.PlayerPerformance{
+			PlayerID:   matrixPlayerID(cells.First()),
+			Name:       name,
+			TeamTag:    cleanText(cells.First().Find(".team-tag").Text()),
+			Agent:      cells.Eq(1).Find("img").AttrOr("title", cells.Eq(1).Find("img").AttrOr("alt", "")),
+			Multikills: make(map[string]*int),
+			Clutches:   make(map[string]*int),
+		}
+		cells.Each(func(i int, td *goquery.Selection) {
+			if i < 2 || i >= len(labels) {
+				return
+			}
+			value := utils.ParseInt(firstField(td.Find(".stats-sq").First().Text()))
+			switch label := labels[i]; {
+			case strings.HasSuffix(label, "k"):
+				p.Multikills[label] = value
+			case strings.HasPrefix(label, "1v"):
+				p.Clutches[label] = value
+			case label == "econ":
+				p.Econ = value
+			case label == "pl":
+				p.Plants = value
+			case label == "de":
+				p.Defuses = value
+			}
+		})
+		players = append(players, p)
+	})
+	return players
+}
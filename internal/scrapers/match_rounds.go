@@ -0,0 +1,144 @@
+package scrapers
+
+import (
+	"bytes"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gofiber/fiber/v2"
+)
+
+//
+// VlrMatchRounds godoc
+// @Summary      Get the round timeline of a match
+// @Description  Returns an ordered list of rounds per map with the winning team, side, win condition and running score
+// @Tags         matches
+// @Produce      json
+// @Param        id   path      int  true  "vlr.gg match ID"
+// @Success      200  {object}  models.SegmentsResponse[models.MapRounds]
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /vlr/match/{id}/rounds [get]
+//
+func VlrMatchRounds(c *fiber.Ctx) error {
+	matchID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || matchID <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid match id"})
+	}
+
+	resp, err := fetchMatchPage(c.UserContext(), matchID, "")
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch match"})
+	}
+	if resp.StatusCode == fiber.StatusNotFound {
+		return c.Status(404).JSON(fiber.Map{"error": "Match not found"})
+	}
+
+	maps, err := ParseMatchRounds(bytes.NewReader(resp.Body))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+	}
+
+	return c.JSON(models.NewSegments(resp.StatusCode, maps))
+}
+
+// ParseMatchRounds extracts the round history of every map on a vlr.gg
+// match page. Maps without a round strip (not yet started) are skipped.
+func ParseMatchRounds(r io.Reader) ([]models.MapRounds, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.MapRounds
+	doc.Find(".vm-stats-game").Each(func(_ int, game *goquery.Selection) {
+		id, err := strconv.Atoi(game.AttrOr("data-game-id", ""))
+		if err != nil {
+			return
+		}
+		rounds := parseRounds(game.Find(".vlr-rounds"))
+		if len(rounds) == 0 {
+			return
+		}
+		header := parseMapHeader(game.Find(".vm-stats-game-header"))
+		result = append(result, models.MapRounds{
+			GameID: id,
+			Map:    header.Map,
+			Team1:  header.Team1.Name,
+			Team2:  header.Team2.Name,
+			Rounds: rounds,
+		})
+	})
+	return result, nil
+}
+
+// parseRounds walks the round columns of a .vlr-rounds strip. Each column
+// holds the round number and one square per team (team 1 on top); the
+// winning square carries mod-win, the side class and a win-type icon. The
+// column title is the running score, e.g. "7-5"; when absent it is counted.
+func parseRounds(strip *goquery.Selection) []models.Round {
+	var rounds []models.Round
+	var score1, score2 int
+	strip.Find(".vlr-rounds-row-col").Each(func(_ int, col *goquery.Selection) {
+		number := utils.ParseInt(col.Find(".rnd-num").Text())
+		if number == nil {
+			return
+		}
+		round := models.Round{Number: *number}
+		col.Find(".rnd-sq").Each(func(i int, sq *goquery.Selection) {
+			if i > 1 || !sq.HasClass("mod-win") {
+				return
+			}
+			round.Winner = i + 1
+			switch {
+			case sq.HasClass("mod-t"):
+				round.WinnerSide = "attack"
+			case sq.HasClass("mod-ct"):
+				round.WinnerSide = "defense"
+			}
+			round.WinType = roundWinType(sq.Find("img").AttrOr("src", ""))
+		})
+		if round.Winner == 0 {
+			// Round not played yet (live map)
+			return
+		}
+
+		switch round.Winner {
+		case 1:
+			score1++
+		case 2:
+			score2++
+		}
+		if w, l, ok := utils.ParseRecord(col.AttrOr("title", "")); ok {
+			score1, score2 = w, l
+		}
+		round.Team1Score, round.Team2Score = score1, score2
+		rounds = append(rounds, round)
+	})
+	return rounds
+}
+
+// roundWinType maps the round icon (e.g. /img/vlr/game/round/boom.webp) to
+// a win condition.
+func roundWinType(src string) string {
+	name := path.Base(src)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	switch name {
+	case "elim":
+		return "elimination"
+	case "boom":
+		return "detonated"
+	case "defuse":
+		return "defused"
+	case "time":
+		return "time"
+	}
+	return ""
+}
@@ -1,185 +1,292 @@
 package scrapers
 
 import (
+	"bytes"
+	"context"
 	"fmt"
-	"net/http"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"math"
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/live"
+	"vlrggapi/internal/refresh"
 	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gofiber/fiber/v2"
 )
 
-func pow(a float64, b int) float64 {
-	return math.Pow(a, float64(b))
-}
-
 //
 // VlrLiveScore godoc
 // @Summary      Get live Valorant match scores
 // @Description  Returns live match scores from VLR.GG
 // @Tags         matches
 // @Produce      json
-// @Success      200  {object}  map[string]interface{}
-// @Failure      500  {object}  map[string]string
+// @Success      200  {object}  models.SegmentsResponse[models.LiveMatch]
+// @Failure      500  {object}  models.ErrorResponse
 // @Router       /vlr/live [get]
 //
 func VlrLiveScore(c *fiber.Ctx) error {
-	url := "https://www.vlr.gg"
-	req, _ := http.NewRequest("GET", url, nil)
-	for k, v := range utils.Headers {
-		req.Header.Set(k, v)
+	var (
+		result    []models.LiveMatch
+		fetchedAt time.Time
+		err       error
+	)
+	// Share the live poller's snapshot so /vlr/live agrees with the stream,
+	// sockets and webhooks and vlr.gg is polled once for all of them.
+	if poller := live.Default(); poller != nil {
+		result, fetchedAt, err = poller.Latest(c.UserContext())
+	} else {
+		result, err = FetchLiveMatches(c.UserContext())
+		fetchedAt = time.Now()
 	}
-	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch live matches"})
 	}
-	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if result == nil {
+		result = []models.LiveMatch{}
+	}
+	resp := models.NewSegments(200, result)
+	// If no live matches, add a message
+	if len(result) == 0 {
+		resp.Data.Message = "No live matches at this time."
+	}
+	resp.Data.Freshness = models.NewFreshness(fetchedAt)
+	return c.JSON(resp)
+}
+
+// liveScores registers /vlr/live with its cache policy. It is not
+// Refreshable: the live poller keeps its data current.
+type liveScores struct{}
+
+func (liveScores) Route() string          { return "/live" }
+func (liveScores) Handler() fiber.Handler { return VlrLiveScore }
+func (liveScores) Description() string    { return "Live match scores" }
+func (liveScores) CachePolicy() cache.Policy {
+	return cache.PolicyFromEnv("live", cache.Policy{TTL: 10 * time.Second, Stale: 20 * time.Second})
+}
+
+func init() {
+	RegisterScraper(liveScores{})
+}
+
+// FetchLiveMatches scrapes the live matches from the vlr.gg home page and
+// enriches each from its match page. Match pages that fail to load are
+// skipped, leaving those fields at their home page values.
+func FetchLiveMatches(ctx context.Context) ([]models.LiveMatch, error) {
+	resp, err := fetch.Get(ctx, utils.BaseURL())
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+		return nil, err
 	}
 
-	var result []map[string]interface{}
-	doc.Find(".js-home-matches-upcoming a.wf-module-item").Each(func(_ int, s *goquery.Selection) {
-		isLive := s.Find(".h-match-eta.mod-live")
-		if isLive.Length() > 0 {
-			teams := []string{}
-			flags := []string{}
-			scores := []string{}
-			roundTexts := []map[string]string{}
-			s.Find(".h-match-team").Each(func(_ int, team *goquery.Selection) {
-				teams = append(teams, strings.TrimSpace(team.Find(".h-match-team-name").Text()))
-				flagClass, _ := team.Find(".flag").Attr("class")
-				flagClass = strings.ReplaceAll(flagClass, " mod-", "")
-				flagClass = strings.ReplaceAll(flagClass, "16", "_")
-				flags = append(flags, flagClass)
-				scores = append(scores, strings.TrimSpace(team.Find(".h-match-team-score").Text()))
-				roundInfoCT := team.Find(".h-match-team-rounds .mod-ct")
-				roundInfoT := team.Find(".h-match-team-rounds .mod-t")
-				roundTextCT := "N/A"
-				roundTextT := "N/A"
-				if roundInfoCT.Length() > 0 {
-					roundTextCT = strings.TrimSpace(roundInfoCT.First().Text())
-				}
-				if roundInfoT.Length() > 0 {
-					roundTextT = strings.TrimSpace(roundInfoT.First().Text())
-				}
-				roundTexts = append(roundTexts, map[string]string{"ct": roundTextCT, "t": roundTextT})
-			})
-
-			eta := "LIVE"
-			matchEvent := strings.TrimSpace(s.Find(".h-match-preview-event").Text())
-			matchSeries := strings.TrimSpace(s.Find(".h-match-preview-series").Text())
-			timestamp := ""
-			if ts, exists := s.Find(".moment-tz-convert").Attr("data-utc-ts"); exists {
-				sec, _ := strconv.ParseInt(ts, 10, 64)
-				timestamp = time.Unix(sec, 0).UTC().Format("2006-01-02 15:04:05")
-			}
-			urlPath, _ := s.Attr("href")
-			urlPath = "https://www.vlr.gg/" + urlPath
-
-			// Fetch match page for team logos, map info, and extra stats
-			teamLogos := []string{"", ""}
-			currentMap := "Unknown"
-			mapNumber := "Unknown"
-			extraStats := map[string]interface{}{}
-
-			matchPageReq, _ := http.NewRequest("GET", urlPath, nil)
-			for k, v := range utils.Headers {
-				matchPageReq.Header.Set(k, v)
-			}
-			matchPageResp, err := http.DefaultClient.Do(matchPageReq)
-			if err == nil {
-				defer matchPageResp.Body.Close()
-				matchDoc, err := goquery.NewDocumentFromReader(matchPageResp.Body)
-				if err == nil {
-					matchDoc.Find(".match-header-vs img").Each(func(i int, img *goquery.Selection) {
-						if i < 2 {
-							src, _ := img.Attr("src")
-							teamLogos[i] = "https:" + src
-						}
-					})
-					activeMap := matchDoc.Find(".vm-stats-gamesnav-item.js-map-switch.mod-active.mod-live")
-					if activeMap.Length() > 0 {
-						mapDiv := activeMap.Find("div")
-						if mapDiv.Length() > 0 {
-							mapText := strings.TrimSpace(mapDiv.Text())
-							mapText = strings.ReplaceAll(mapText, "\n", "")
-							mapText = strings.ReplaceAll(mapText, "\t", "")
-							currentMap = mapText
-							re := regexp.MustCompile(`^\d+`)
-							mapNumberMatch := re.FindString(mapText)
-							if mapNumberMatch != "" {
-								mapNumber = mapNumberMatch
-								currentMap = strings.TrimSpace(strings.TrimPrefix(mapText, mapNumberMatch))
-							}
-						}
-					}
-					// (player stats scraping removed)
-				}
-			}
+	result, err := ParseLiveMatches(bytes.NewReader(resp.Body))
+	if err != nil {
+		return nil, err
+	}
 
-			team1RoundCT := "N/A"
-			team1RoundT := "N/A"
-			team2RoundCT := "N/A"
-			team2RoundT := "N/A"
-			if len(roundTexts) > 0 {
-				team1RoundCT = roundTexts[0]["ct"]
-				team1RoundT = roundTexts[0]["t"]
+	// Fetch each match page for team logos and map info, concurrently; the
+	// fetcher's per-host limit bounds how many run at once.
+	var wg sync.WaitGroup
+	for i := range result {
+		wg.Add(1)
+		go func(m *models.LiveMatch) {
+			defer wg.Done()
+			matchPageResp, err := fetch.Get(ctx, m.MatchPage)
+			if err != nil {
+				return
 			}
-			if len(roundTexts) > 1 {
-				team2RoundCT = roundTexts[1]["ct"]
-				team2RoundT = roundTexts[1]["t"]
+			page, err := ParseLiveMatchPage(bytes.NewReader(matchPageResp.Body))
+			if err != nil {
+				return
 			}
+			page.apply(m)
+		}(&result[i])
+	}
+	wg.Wait()
+	return result, nil
+}
 
-			entry := map[string]interface{}{
-				"team1":           teams[0],
-				"team2":           teams[1],
-				"flag1":           flags[0],
-				"flag2":           flags[1],
-				"team1_logo":      teamLogos[0],
-				"team2_logo":      teamLogos[1],
-				"score1":          scores[0],
-				"score2":          scores[1],
-				"team1_round_ct":  team1RoundCT,
-				"team1_round_t":   team1RoundT,
-				"team2_round_ct":  team2RoundCT,
-				"team2_round_t":   team2RoundT,
-				"map_number":      mapNumber,
-				"current_map":     currentMap,
-				"time_until_match": eta,
-				"match_event":     matchEvent,
-				"match_series":    matchSeries,
-				"unix_timestamp":  timestamp,
-				"match_page":      urlPath,
+// ParseLiveMatches extracts the matches currently marked live from the vlr.gg
+// home page. Team logos and map details come from the match page and are
+// filled in separately via ParseLiveMatchPage.
+func ParseLiveMatches(r io.Reader) ([]models.LiveMatch, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.LiveMatch
+	doc.Find(".js-home-matches-upcoming a.wf-module-item").Each(func(_ int, s *goquery.Selection) {
+		isLive := s.Find(".h-match-eta.mod-live")
+		if isLive.Length() == 0 {
+			return
+		}
+		teams := []string{}
+		flags := []string{}
+		scores := []string{}
+		roundTexts := []map[string]string{}
+		s.Find(".h-match-team").Each(func(_ int, team *goquery.Selection) {
+			teams = append(teams, strings.TrimSpace(team.Find(".h-match-team-name").Text()))
+			flagClass, _ := team.Find(".flag").Attr("class")
+			flags = append(flags, flagClass)
+			scores = append(scores, strings.TrimSpace(team.Find(".h-match-team-score").Text()))
+			roundInfoCT := team.Find(".h-match-team-rounds .mod-ct")
+			roundInfoT := team.Find(".h-match-team-rounds .mod-t")
+			roundTextCT := "N/A"
+			roundTextT := "N/A"
+			if roundInfoCT.Length() > 0 {
+				roundTextCT = strings.TrimSpace(roundInfoCT.First().Text())
 			}
-			// Add extra stats if available
-			for k, v := range extraStats {
-				entry[k] = v
+			if roundInfoT.Length() > 0 {
+				roundTextT = strings.TrimSpace(roundInfoT.First().Text())
 			}
-			result = append(result, entry)
+			roundTexts = append(roundTexts, map[string]string{"ct": roundTextCT, "t": roundTextT})
+		})
+		if len(teams) < 2 {
+			return
 		}
-	})
 
-	// If no live matches, add a message
-	if len(result) == 0 {
-		return c.JSON(fiber.Map{
-			"data": fiber.Map{
-				"status": 200,
-				"segments": []interface{}{},
-				"message": "No live matches at this time.",
-			},
+		eta := "LIVE"
+		matchEvent := strings.TrimSpace(s.Find(".h-match-preview-event").Text())
+		matchSeries := strings.TrimSpace(s.Find(".h-match-preview-series").Text())
+		ts, _ := s.Find(".moment-tz-convert").Attr("data-utc-ts")
+		var unixTimestamp *int64
+		var startedAt *time.Time
+		if sec, err := strconv.ParseInt(strings.TrimSpace(ts), 10, 64); err == nil {
+			t := time.Unix(sec, 0).UTC()
+			unixTimestamp, startedAt = &sec, &t
+		}
+		urlPath, _ := s.Attr("href")
+		matchID, matchSlug := utils.EntityID(urlPath, "")
+		urlPath = utils.AbsoluteURL(urlPath)
+
+		team1RoundCT := "N/A"
+		team1RoundT := "N/A"
+		team2RoundCT := "N/A"
+		team2RoundT := "N/A"
+		if len(roundTexts) > 0 {
+			team1RoundCT = roundTexts[0]["ct"]
+			team1RoundT = roundTexts[0]["t"]
+		}
+		if len(roundTexts) > 1 {
+			team2RoundCT = roundTexts[1]["ct"]
+			team2RoundT = roundTexts[1]["t"]
+		}
+
+		result = append(result, models.LiveMatch{
+			MatchID:        matchID,
+			MatchSlug:      matchSlug,
+			Team1:          teams[0],
+			Team2:          teams[1],
+			Country1:       country(flags[0], ""),
+			Country2:       country(flags[1], ""),
+			Score1:         utils.ParseInt(scores[0]),
+			Score2:         utils.ParseInt(scores[1]),
+			Team1RoundCT:   utils.ParseInt(team1RoundCT),
+			Team1RoundT:    utils.ParseInt(team1RoundT),
+			Team2RoundCT:   utils.ParseInt(team2RoundCT),
+			Team2RoundT:    utils.ParseInt(team2RoundT),
+			CurrentMap:     "Unknown",
+			TimeUntilMatch: eta,
+			MatchEvent:     matchEvent,
+			MatchSeries:    matchSeries,
+			UnixTimestamp:  unixTimestamp,
+			StartedAt:      startedAt,
+			MatchPage:      urlPath,
+			Raw: rawStrings(
+				"score1", scores[0],
+				"score2", scores[1],
+				"team1_round_ct", team1RoundCT,
+				"team1_round_t", team1RoundT,
+				"team2_round_ct", team2RoundCT,
+				"team2_round_t", team2RoundT,
+				"unix_timestamp", ts,
+				"country1", flags[0],
+				"country2", flags[1],
+			),
 		})
+	})
+	return result, nil
+}
+
+// LiveMatchPage holds the details of a live match that are only available on
+// its match page.
+type LiveMatchPage struct {
+	Team1ID    int
+	Team2ID    int
+	EventID    int
+	Team1Logo  string
+	Team2Logo  string
+	MapNumber  *int
+	CurrentMap string
+}
+
+func (p LiveMatchPage) apply(m *models.LiveMatch) {
+	m.Team1ID = p.Team1ID
+	m.Team2ID = p.Team2ID
+	m.EventID = p.EventID
+	m.Team1Logo = p.Team1Logo
+	m.Team2Logo = p.Team2Logo
+	m.MapNumber = p.MapNumber
+	m.CurrentMap = p.CurrentMap
+}
+
+// ParseLiveMatchPage extracts the team logos and the map currently being
+// played from a live vlr.gg match page.
+func ParseLiveMatchPage(r io.Reader) (LiveMatchPage, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return LiveMatchPage{}, err
 	}
 
-	return c.JSON(fiber.Map{"data": fiber.Map{"status": 200, "segments": result}})
+	teamIDs := []int{0, 0}
+	teamLogos := []string{"", ""}
+	currentMap := "Unknown"
+	var mapNumber *int
+	doc.Find("a.match-header-link").Each(func(i int, a *goquery.Selection) {
+		if i < 2 {
+			teamIDs[i], _ = utils.EntityID(a.AttrOr("href", ""), "team")
+		}
+	})
+	eventID, _ := utils.EntityID(doc.Find("a.match-header-event").AttrOr("href", ""), "event")
+	doc.Find(".match-header-vs img").Each(func(i int, img *goquery.Selection) {
+		if i < 2 {
+			src, _ := img.Attr("src")
+			teamLogos[i] = utils.AbsoluteURL(src)
+		}
+	})
+	activeMap := doc.Find(".vm-stats-gamesnav-item.js-map-switch.mod-active.mod-live")
+	if activeMap.Length() > 0 {
+		mapDiv := activeMap.Find("div")
+		if mapDiv.Length() > 0 {
+			mapText := strings.TrimSpace(mapDiv.Text())
+			mapText = strings.ReplaceAll(mapText, "\n", "")
+			mapText = strings.ReplaceAll(mapText, "\t", "")
+			currentMap = mapText
+			re := regexp.MustCompile(`^\d+`)
+			mapNumberMatch := re.FindString(mapText)
+			if mapNumberMatch != "" {
+				mapNumber = utils.ParseInt(mapNumberMatch)
+				currentMap = strings.TrimSpace(strings.TrimPrefix(mapText, mapNumberMatch))
+			}
+		}
+	}
+	return LiveMatchPage{
+		Team1ID:    teamIDs[0],
+		Team2ID:    teamIDs[1],
+		EventID:    eventID,
+		Team1Logo:  teamLogos[0],
+		Team2Logo:  teamLogos[1],
+		MapNumber:  mapNumber,
+		CurrentMap: currentMap,
+	}, nil
 }
 
 //
@@ -196,111 +303,79 @@ func VlrLiveScore(c *fiber.Ctx) error {
 // @Param        max_retries   query     int     false  "Retry attempts per page (results only)"    default(3)
 // @Param        request_delay query     number  false  "Delay between requests (seconds, results only)" default(1.0)
 // @Param        timeout       query     int     false  "HTTP timeout (seconds, results only)"     default(30)
-// @Success      200  {object}  map[string]interface{}
-// @Failure      400  {object}  map[string]string
-// @Failure      500  {object}  map[string]string
+// @Success      200  {object}  models.SegmentsResponse[models.MatchResult]
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
 // @Router       /vlr/match [get]
 //
 func VlrMatchResults(c *fiber.Ctx) error {
-	// Determine if schedule or results
-	querySchedule := c.Query("schedule")
-	queryResults := c.Query("results")
-	isSchedule := querySchedule == "true" || querySchedule == "1" || (queryResults == "" && querySchedule != "false")
-	isResults := queryResults == "true" || queryResults == "1" || (querySchedule == "" && queryResults != "false")
-
-	// If both ?schedule and ?results are missing, default to results
-	if !isSchedule && !isResults {
-		isResults = true
-	}
+	return matchesScraper.serve(c)
+}
 
-	if isSchedule {
-		// Scrape from /matches (schedule)
-		url := "https://www.vlr.gg/matches"
-		req, _ := http.NewRequest("GET", url, nil)
-		for k, v := range utils.Headers {
-			req.Header.Set(k, v)
+// matchesScraper stores either a schedule or a results envelope depending on
+// the request, hence the any.
+var matchesScraper = &storedScraper[any]{
+	name:        "match",
+	route:       "/match",
+	description: "Match schedule and results",
+	interval:    refresh.Interval("match", 5*time.Minute),
+	policy:      cache.PolicyFromEnv("match", cache.Policy{TTL: time.Minute, Stale: 5 * time.Minute}),
+	variants:    []string{"", "results=true"},
+	key:         matchKey,
+	scrape:      scrapeMatches,
+	stamp: func(resp any, f models.Freshness) any {
+		switch r := resp.(type) {
+		case models.SegmentsResponse[models.ScheduledMatch]:
+			return stampSegments(r, f)
+		case models.SegmentsResponse[models.MatchResult]:
+			return stampSegments(r, f)
 		}
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch match schedule"})
-		}
-		defer resp.Body.Close()
+		return resp
+	},
+}
 
-		doc, err := goquery.NewDocumentFromReader(resp.Body)
-		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
-		}
+func init() {
+	RegisterScraper(matchesScraper)
+}
 
-		var result []map[string]interface{}
-		doc.Find("a.wf-module-item").Each(func(_ int, s *goquery.Selection) {
-			matchTime := strings.TrimSpace(s.Find("div.match-item-time").Text())
-			team1 := strings.TrimSpace(s.Find("div.match-item-vs-team:first-child .text-of").Text())
-			team2 := strings.TrimSpace(s.Find("div.match-item-vs-team:last-child .text-of").Text())
-			flag1 := s.Find("div.match-item-vs-team:first-child .flag").AttrOr("class", "")
-			flag2 := s.Find("div.match-item-vs-team:last-child .flag").AttrOr("class", "")
-			flag1 = strings.ReplaceAll(flag1, " mod-", "_")
-			flag2 = strings.ReplaceAll(flag2, " mod-", "_")
-			// Extract event: get the last non-empty line (should be event name)
-			eventRaw := s.Find("div.match-item-event").Text()
-			event := ""
-			eventLines := strings.Split(eventRaw, "\n")
-			for i := len(eventLines) - 1; i >= 0; i-- {
-				line := strings.TrimSpace(eventLines[i])
-				if line != "" {
-					event = line
-					break
-				}
-			}
-			series := strings.TrimSpace(s.Find("div.match-item-event-series").Text())
-			// status is not included in schedule output
-			etaRaw := s.Find("div.match-item-eta").Text()
-			eta := ""
-			// Improved: get the last non-empty line (should be the time, e.g. "18m")
-			etaLines := strings.Split(etaRaw, "\n")
-			for i := len(etaLines) - 1; i >= 0; i-- {
-				line := strings.TrimSpace(etaLines[i])
-				if line != "" {
-					eta = line
-					break
-				}
-			}
-			urlPath, _ := s.Attr("href")
-			result = append(result, map[string]interface{}{
-				"match_time": matchTime,
-				"team1":      team1,
-				"team2":      team2,
-				"flag1":      flag1,
-				"flag2":      flag2,
-				"event":      event,
-				"series":     series,
-				"eta":        eta,
-				"match_page": "https://www.vlr.gg" + urlPath,
-			})
-		})
+// matchQuery is a parsed /vlr/match request.
+type matchQuery struct {
+	schedule                       bool
+	startPage, endPage, totalPages int
+	maxRetries                     int
+	requestDelay                   float64
+	timeout                        int
+}
 
-		return c.JSON(fiber.Map{"data": fiber.Map{"status": 200, "segments": result}})
+func parseMatchQuery(q map[string]string) (matchQuery, error) {
+	// Determine if schedule or results
+	querySchedule := q["schedule"]
+	queryResults := q["results"]
+	isSchedule := querySchedule == "true" || querySchedule == "1" || (queryResults == "" && querySchedule != "false")
+	if isSchedule {
+		return matchQuery{schedule: true}, nil
 	}
 
 	// Default: results
-	numPages, _ := strconv.Atoi(c.Query("num_pages", "1"))
-	fromPageStr := c.Query("from_page")
-	toPageStr := c.Query("to_page")
-	maxRetries, _ := strconv.Atoi(c.Query("max_retries", "3"))
-	requestDelay, _ := strconv.ParseFloat(c.Query("request_delay", "1.0"), 64)
-	timeout, _ := strconv.Atoi(c.Query("timeout", "30"))
+	numPages, _ := strconv.Atoi(queryOr(q, "num_pages", "1"))
+	fromPageStr := q["from_page"]
+	toPageStr := q["to_page"]
+	maxRetries, _ := strconv.Atoi(queryOr(q, "max_retries", "3"))
+	requestDelay, _ := strconv.ParseFloat(queryOr(q, "request_delay", "1.0"), 64)
+	timeout, _ := strconv.Atoi(queryOr(q, "timeout", "30"))
 
 	var fromPage, toPage int
 	var err error
 	if fromPageStr != "" {
 		fromPage, err = strconv.Atoi(fromPageStr)
 		if err != nil || fromPage < 1 {
-			return c.Status(400).JSON(fiber.Map{"error": "Invalid from_page"})
+			return matchQuery{}, &httpError{400, "Invalid from_page", nil}
 		}
 	}
 	if toPageStr != "" {
 		toPage, err = strconv.Atoi(toPageStr)
 		if err != nil || toPage < 1 {
-			return c.Status(400).JSON(fiber.Map{"error": "Invalid to_page"})
+			return matchQuery{}, &httpError{400, "Invalid to_page", nil}
 		}
 	}
 
@@ -323,173 +398,256 @@ func VlrMatchResults(c *fiber.Ctx) error {
 		totalPages = endPage - startPage + 1
 	}
 
-	type MatchResult struct {
-		Team1          string `json:"team1"`
-		Team2          string `json:"team2"`
-		Score1         string `json:"score1"`
-		Score2         string `json:"score2"`
-		Flag1          string `json:"flag1"`
-		Flag2          string `json:"flag2"`
-		TimeCompleted  string `json:"time_completed"`
-		RoundInfo      string `json:"round_info"`
-		TournamentName string `json:"tournament_name"`
-		MatchPage      string `json:"match_page"`
-		TournamentIcon string `json:"tournament_icon"`
-		PageNumber     int    `json:"page_number"`
-	}
+	return matchQuery{
+		startPage:    startPage,
+		endPage:      endPage,
+		totalPages:   totalPages,
+		maxRetries:   maxRetries,
+		requestDelay: requestDelay,
+		timeout:      timeout,
+	}, nil
+}
 
-	var result []MatchResult
-	var failedPages []int
+// matchKey keys results by page range only; retry and timeout settings do
+// not change the data.
+func matchKey(q map[string]string) (string, error) {
+	mq, err := parseMatchQuery(q)
+	if err != nil {
+		return "", err
+	}
+	if mq.schedule {
+		return "schedule", nil
+	}
+	return fmt.Sprintf("results=%d-%d", mq.startPage, mq.endPage), nil
+}
 
-	client := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+func scrapeMatches(ctx context.Context, q map[string]string) (any, error) {
+	mq, err := parseMatchQuery(q)
+	if err != nil {
+		return nil, err
+	}
 
-	for page := startPage; page <= endPage; page++ {
-		pageSuccess := false
-		retryCount := 0
+	if mq.schedule {
+		// Scrape from /matches (schedule)
+		url := utils.BaseURL() + "/matches"
+		resp, err := fetch.Get(ctx, url)
+		if err != nil {
+			return nil, &httpError{500, "Failed to fetch match schedule", err}
+		}
 
-		for !pageSuccess && retryCount < maxRetries {
-			var url string
-			if page == 1 {
-				url = "https://www.vlr.gg/matches/results"
-			} else {
-				url = fmt.Sprintf("https://www.vlr.gg/matches/results/?page=%d", page)
-			}
+		result, err := ParseSchedule(bytes.NewReader(resp.Body))
+		if err != nil {
+			return nil, &httpError{500, "Failed to parse HTML", err}
+		}
 
-			req, _ := http.NewRequest("GET", url, nil)
-			for k, v := range utils.Headers {
-				req.Header.Set(k, v)
-			}
-			resp, err := client.Do(req)
-			if err != nil {
-				retryCount++
-				if retryCount < maxRetries {
-					sleepDuration := time.Duration(float64(requestDelay) * float64(time.Second) * pow(2, retryCount))
-					time.Sleep(sleepDuration)
-				}
-				continue
-			}
-			defer resp.Body.Close()
+		return models.NewSegments(200, result), nil
+	}
 
-			doc, err := goquery.NewDocumentFromReader(resp.Body)
-			if err != nil {
-				retryCount++
-				if retryCount < maxRetries {
-					sleepDuration := time.Duration(float64(requestDelay) * float64(time.Second) * pow(2, retryCount))
-					time.Sleep(sleepDuration)
-				}
-				continue
-			}
+	var result []models.MatchResult
+	var failedPages []int
 
-			items := doc.Find("a.wf-module-item")
-			if items.Length() == 0 {
-				pageSuccess = true
-				break
-			}
+	for page := mq.startPage; page <= mq.endPage; page++ {
+		var url string
+		if page == 1 {
+			url = utils.BaseURL() + "/matches/results"
+		} else {
+			url = fmt.Sprintf("%s/matches/results/?page=%d", utils.BaseURL(), page)
+		}
 
-			items.Each(func(_ int, s *goquery.Selection) {
-				urlPath, _ := s.Attr("href")
-
-				// Parse team names and scores from the new HTML structure
-				vs := s.Find(".match-item-vs")
-				team1 := ""
-				team2 := ""
-				score1 := ""
-				score2 := ""
-				flag1 := ""
-				flag2 := ""
-
-				teams := vs.Find(".match-item-vs-team")
-				if teams.Length() >= 2 {
-					team1Div := teams.Eq(0)
-					team2Div := teams.Eq(1)
-
-					team1 = strings.TrimSpace(team1Div.Find(".match-item-vs-team-name .text-of").Text())
-					team2 = strings.TrimSpace(team2Div.Find(".match-item-vs-team-name .text-of").Text())
-					score1 = strings.TrimSpace(team1Div.Find(".match-item-vs-team-score").Text())
-					score2 = strings.TrimSpace(team2Div.Find(".match-item-vs-team-score").Text())
-
-					flag1Sel := team1Div.Find(".match-item-vs-team-name .flag")
-					flag2Sel := team2Div.Find(".match-item-vs-team-name .flag")
-					if flag1Sel.Length() > 0 {
-						class, _ := flag1Sel.Attr("class")
-						class = strings.ReplaceAll(class, " mod-", "_")
-						flag1 = class
-					}
-					if flag2Sel.Length() > 0 {
-						class, _ := flag2Sel.Attr("class")
-						class = strings.ReplaceAll(class, " mod-", "_")
-						flag2 = class
-					}
-				}
-
-				// Fallback for time completed and event info
-				divs := s.Find("div")
-				clean := func(str string) string {
-					return strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(str, "\t", ""), "\n", ""))
-				}
-				timeCompleted := clean(divs.Eq(0).Text())
-
-				roundInfo := ""
-				tournamentName := ""
-				tournamentIcon := ""
-				divs.Each(func(i int, d *goquery.Selection) {
-					class, _ := d.Attr("class")
-					if strings.Contains(class, "match-item-event-series") {
-						roundInfo = clean(d.Text())
-					}
-					if strings.Contains(class, "match-item-event") {
-						tournamentName = clean(d.Text())
-						img := d.Find("img")
-						if img.Length() > 0 {
-							tournamentIcon, _ = img.Attr("src")
-							if !strings.HasPrefix(tournamentIcon, "http") {
-								tournamentIcon = "https:" + tournamentIcon
-							}
-						}
-					}
-				})
-
-				result = append(result, MatchResult{
-					Team1:          team1,
-					Team2:          team2,
-					Score1:         score1,
-					Score2:         score2,
-					Flag1:          flag1,
-					Flag2:          flag2,
-					TimeCompleted:  timeCompleted,
-					RoundInfo:      roundInfo,
-					TournamentName: tournamentName,
-					MatchPage:      urlPath,
-					TournamentIcon: tournamentIcon,
-					PageNumber:     page,
-				})
-			})
-
-			pageSuccess = true
-			if page < endPage {
-				time.Sleep(time.Duration(requestDelay * float64(time.Second)))
-			}
+		// Retries with exponential backoff are handled by the fetcher.
+		resp, err := fetch.Do(ctx, fetch.Request{
+			URL:         url,
+			MaxAttempts: mq.maxRetries,
+			RetryDelay:  time.Duration(mq.requestDelay * float64(time.Second)),
+			Timeout:     time.Duration(mq.timeout) * time.Second,
+		})
+		if err != nil {
+			failedPages = append(failedPages, page)
+			continue
 		}
 
-		if !pageSuccess {
+		matches, err := ParseResults(bytes.NewReader(resp.Body), page)
+		if err != nil {
 			failedPages = append(failedPages, page)
+			continue
 		}
-	}
+		result = append(result, matches...)
 
-	segments := fiber.Map{
-		"status": 200,
-		"segments": result,
-		"meta": fiber.Map{
-			"page_range":            fmt.Sprintf("%d-%d", startPage, endPage),
-			"total_pages_requested": totalPages,
-			"successful_pages":      totalPages - len(failedPages),
-			"failed_pages":          failedPages,
-			"total_matches":         len(result),
-		},
+		if page < mq.endPage {
+			time.Sleep(time.Duration(mq.requestDelay * float64(time.Second)))
+		}
 	}
-	data := fiber.Map{"data": segments}
+
 	if len(result) == 0 {
-		return c.Status(500).JSON(fiber.Map{"error": fmt.Sprintf("No data retrieved. Failed pages: %v", failedPages)})
+		return nil, &httpError{500, fmt.Sprintf("No data retrieved. Failed pages: %v", failedPages), nil}
+	}
+	data := models.NewSegments(200, result)
+	data.Data.Meta = &models.ResultsMeta{
+		PageRange:           fmt.Sprintf("%d-%d", mq.startPage, mq.endPage),
+		TotalPagesRequested: mq.totalPages,
+		SuccessfulPages:     mq.totalPages - len(failedPages),
+		FailedPages:         failedPages,
+		TotalMatches:        len(result),
+	}
+	return data, nil
+}
+
+// queryOr returns q[key], or def when it is missing or empty.
+func queryOr(q map[string]string, key, def string) string {
+	if v := q[key]; v != "" {
+		return v
+	}
+	return def
+}
+
+// ParseSchedule extracts upcoming matches from a vlr.gg /matches page.
+func ParseSchedule(r io.Reader) ([]models.ScheduledMatch, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock()
+	var result []models.ScheduledMatch
+	doc.Find("a.wf-module-item").Each(func(_ int, s *goquery.Selection) {
+		matchTime := strings.TrimSpace(s.Find("div.match-item-time").Text())
+		team1 := strings.TrimSpace(s.Find("div.match-item-vs-team:first-child .text-of").Text())
+		team2 := strings.TrimSpace(s.Find("div.match-item-vs-team:last-child .text-of").Text())
+		flag1 := s.Find("div.match-item-vs-team:first-child .flag").AttrOr("class", "")
+		flag2 := s.Find("div.match-item-vs-team:last-child .flag").AttrOr("class", "")
+		// Extract event: get the last non-empty line (should be event name)
+		eventRaw := s.Find("div.match-item-event").Text()
+		event := ""
+		eventLines := strings.Split(eventRaw, "\n")
+		for i := len(eventLines) - 1; i >= 0; i-- {
+			line := strings.TrimSpace(eventLines[i])
+			if line != "" {
+				event = line
+				break
+			}
+		}
+		series := strings.TrimSpace(s.Find("div.match-item-event-series").Text())
+		// status is not included in schedule output
+		etaRaw := s.Find("div.match-item-eta").Text()
+		eta := ""
+		// Improved: get the last non-empty line (should be the time, e.g. "18m")
+		etaLines := strings.Split(etaRaw, "\n")
+		for i := len(etaLines) - 1; i >= 0; i-- {
+			line := strings.TrimSpace(etaLines[i])
+			if line != "" {
+				eta = line
+				break
+			}
+		}
+		urlPath, _ := s.Attr("href")
+		matchID, matchSlug := utils.EntityID(urlPath, "")
+		result = append(result, models.ScheduledMatch{
+			MatchID:   matchID,
+			MatchSlug: matchSlug,
+			MatchTime: matchTime,
+			Team1:     team1,
+			Team2:     team2,
+			Country1:  country(flag1, ""),
+			Country2:  country(flag2, ""),
+			Event:     event,
+			Series:    series,
+			ETA:       eta,
+			StartsAt:  parseRelativeTime(eta, now),
+			MatchPage: utils.AbsoluteURL(urlPath),
+			Raw: rawStrings(
+				"starts_at", eta,
+				"country1", flag1,
+				"country2", flag2,
+			),
+		})
+	})
+
+	return result, nil
+}
+
+// ParseResults extracts completed matches from a vlr.gg /matches/results
+// page, tagging each with the page number it came from.
+func ParseResults(r io.Reader, page int) ([]models.MatchResult, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
 	}
-	return c.JSON(data)
+
+	now := clock()
+	var result []models.MatchResult
+	doc.Find("a.wf-module-item").Each(func(_ int, s *goquery.Selection) {
+		urlPath, _ := s.Attr("href")
+
+		// Parse team names and scores from the new HTML structure
+		vs := s.Find(".match-item-vs")
+		team1 := ""
+		team2 := ""
+		score1 := ""
+		score2 := ""
+		flag1 := ""
+		flag2 := ""
+
+		teams := vs.Find(".match-item-vs-team")
+		if teams.Length() >= 2 {
+			team1Div := teams.Eq(0)
+			team2Div := teams.Eq(1)
+
+			team1 = strings.TrimSpace(team1Div.Find(".match-item-vs-team-name .text-of").Text())
+			team2 = strings.TrimSpace(team2Div.Find(".match-item-vs-team-name .text-of").Text())
+			score1 = strings.TrimSpace(team1Div.Find(".match-item-vs-team-score").Text())
+			score2 = strings.TrimSpace(team2Div.Find(".match-item-vs-team-score").Text())
+
+			flag1Sel := team1Div.Find(".match-item-vs-team-name .flag")
+			flag2Sel := team2Div.Find(".match-item-vs-team-name .flag")
+			if flag1Sel.Length() > 0 {
+				flag1, _ = flag1Sel.Attr("class")
+			}
+			if flag2Sel.Length() > 0 {
+				flag2, _ = flag2Sel.Attr("class")
+			}
+		}
+
+		// Fallback for time completed and event info
+		divs := s.Find("div")
+		clean := func(str string) string {
+			return strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(str, "\t", ""), "\n", ""))
+		}
+		timeCompleted := clean(divs.Eq(0).Text())
+		ago := clean(s.Find(".ml-eta").Text())
+
+		// The event cell nests the series above the tournament name, and the
+		// tournament icon sits in its own cell after it.
+		event := s.Find(".match-item-event").First().Clone()
+		roundInfo := clean(event.Find(".match-item-event-series").Text())
+		event.Find(".match-item-event-series").Remove()
+		tournamentName := clean(event.Text())
+		tournamentIcon := utils.AbsoluteURL(s.Find(".match-item-icon img, .match-item-event img").First().AttrOr("src", ""))
+
+		matchID, matchSlug := utils.EntityID(urlPath, "")
+		result = append(result, models.MatchResult{
+			MatchID:        matchID,
+			MatchSlug:      matchSlug,
+			Team1:          team1,
+			Team2:          team2,
+			Score1:         utils.ParseInt(score1),
+			Score2:         utils.ParseInt(score2),
+			Country1:       country(flag1, ""),
+			Country2:       country(flag2, ""),
+			TimeCompleted:  timeCompleted,
+			CompletedAt:    parseRelativeTime(ago, now),
+			RoundInfo:      roundInfo,
+			TournamentName: tournamentName,
+			MatchPage:      utils.AbsoluteURL(urlPath),
+			TournamentIcon: tournamentIcon,
+			PageNumber:     page,
+			Raw: rawStrings(
+				"score1", score1,
+				"score2", score2,
+				"completed_at", ago,
+				"country1", flag1,
+				"country2", flag2,
+			),
+		})
+	})
+	return result, nil
 }
@@ -1,10 +1,17 @@
 package scrapers
 
 import (
-	"net/http"
+	"bytes"
+	"context"
+	"io"
 	"strings"
+	"time"
 
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/refresh"
 	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gofiber/fiber/v2"
@@ -13,31 +20,56 @@ import (
 //
 // VlrNews godoc
 // @Summary      Get latest Valorant news
-// @Description  Returns a list of recent Valorant news articles
+// @Description  Returns a list of recent Valorant news articles, served from the background-refreshed store
 // @Tags         news
 // @Produce      json
-// @Success      200  {object}  map[string]interface{}
-// @Failure      500  {object}  map[string]string
+// @Success      200  {object}  models.SegmentsResponse[models.NewsArticle]
+// @Failure      500  {object}  models.ErrorResponse
 // @Router       /vlr/news [get]
 //
 func VlrNews(c *fiber.Ctx) error {
-	url := "https://www.vlr.gg/news"
-	req, _ := http.NewRequest("GET", url, nil)
-	for k, v := range utils.Headers {
-		req.Header.Set(k, v)
+	return newsScraper.serve(c)
+}
+
+var newsScraper = &storedScraper[models.SegmentsResponse[models.NewsArticle]]{
+	name:        "news",
+	route:       "/news",
+	description: "Latest Valorant news articles",
+	interval:    refresh.Interval("news", 10*time.Minute),
+	policy:      cache.PolicyFromEnv("news", cache.Policy{TTL: 5 * time.Minute, Stale: 10 * time.Minute}),
+	variants:    []string{""},
+	key:         noQuery,
+	scrape:      scrapeNews,
+	stamp:       stampSegments[models.NewsArticle],
+}
+
+func init() {
+	RegisterScraper(newsScraper)
+}
+
+func scrapeNews(ctx context.Context, _ map[string]string) (models.SegmentsResponse[models.NewsArticle], error) {
+	url := utils.BaseURL() + "/news"
+	resp, err := fetch.Get(ctx, url)
+	if err != nil {
+		return models.SegmentsResponse[models.NewsArticle]{}, &httpError{500, "Failed to fetch news", err}
 	}
-	resp, err := http.DefaultClient.Do(req)
+
+	result, err := ParseNews(bytes.NewReader(resp.Body))
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch news"})
+		return models.SegmentsResponse[models.NewsArticle]{}, &httpError{500, "Failed to parse HTML", err}
 	}
-	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	return models.NewSegments(resp.StatusCode, result), nil
+}
+
+// ParseNews extracts the article list from a vlr.gg /news page.
+func ParseNews(r io.Reader) ([]models.NewsArticle, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+		return nil, err
 	}
 
-	var result []map[string]string
+	var result []models.NewsArticle
 	doc.Find("a.wf-module-item").Each(func(i int, s *goquery.Selection) {
 		dateAuthor := s.Find("div.ge-text-light").Text()
 		parts := strings.Split(dateAuthor, "by")
@@ -70,14 +102,17 @@ func VlrNews(c *fiber.Ctx) error {
 		desc = strings.TrimSpace(desc)
 
 		urlPath, _ := s.Attr("href")
-		result = append(result, map[string]string{
-			"title":       title,
-			"description": desc,
-			"date":        date,
-			"author":      author,
-			"url_path":    "https://vlr.gg" + urlPath,
+		articleID, slug := utils.EntityID(urlPath, "")
+		result = append(result, models.NewsArticle{
+			ArticleID:   articleID,
+			Slug:        slug,
+			Title:       title,
+			Description: desc,
+			Date:        parseDate(date),
+			Author:      author,
+			URLPath:     utils.AbsoluteURL(urlPath),
+			Raw:         rawStrings("date", date),
 		})
 	})
-
-	return c.JSON(fiber.Map{"data": fiber.Map{"status": resp.StatusCode, "segments": result}})
+	return result, nil
 }
@@ -0,0 +1,125 @@
+package scrapers
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+)
+
+// update rewrites the golden files from the current parser output:
+//
+//	go test ./internal/scrapers -run TestParsers -update
+var update = flag.Bool("update", false, "rewrite testdata/*.golden.json")
+
+// fixedNow is the clock the fixtures were saved against, so relative times
+// ("2h ago", "in 3d") resolve to the same timestamps on every run.
+var fixedNow = time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC)
+
+func TestMain(m *testing.M) {
+	clock = func() time.Time { return fixedNow }
+	os.Exit(m.Run())
+}
+
+// eventMatches groups both halves of ParseEventMatches into one golden.
+type eventMatches struct {
+	Schedule []models.ScheduledMatch `json:"schedule"`
+	Results  []models.MatchResult    `json:"results"`
+}
+
+func TestParsers(t *testing.T) {
+	if utils.BaseURL() != utils.DefaultBaseURL {
+		t.Skip("goldens hold absolute links against " + utils.DefaultBaseURL + "; unset VLR_BASE_URL")
+	}
+
+	tests := []struct {
+		name    string
+		fixture string
+		parse   func(io.Reader) (any, error)
+	}{
+		{"rankings", "rankings.html", func(r io.Reader) (any, error) { return ParseRankings(r) }},
+		{"news", "news.html", func(r io.Reader) (any, error) { return ParseNews(r) }},
+		{"stats", "stats.html", func(r io.Reader) (any, error) { return ParseStats(r) }},
+		{"events", "events.html", func(r io.Reader) (any, error) { return ParseEvents(r, true, true) }},
+		{"events_upcoming", "events.html", func(r io.Reader) (any, error) { return ParseEvents(r, true, false) }},
+		{"live_matches", "home.html", func(r io.Reader) (any, error) { return ParseLiveMatches(r) }},
+		{"live_match_page", "match_live.html", func(r io.Reader) (any, error) { return ParseLiveMatchPage(r) }},
+		{"schedule", "matches.html", func(r io.Reader) (any, error) { return ParseSchedule(r) }},
+		{"results", "matches_results.html", func(r io.Reader) (any, error) { return ParseResults(r, 2) }},
+		{"match_detail", "match.html", func(r io.Reader) (any, error) { return ParseMatchDetail(r) }},
+		{"match_rounds", "match_live.html", func(r io.Reader) (any, error) { return ParseMatchRounds(r) }},
+		{"match_economy", "match_economy.html", func(r io.Reader) (any, error) { return ParseMatchEconomy(r) }},
+		{"match_performance", "match_performance.html", func(r io.Reader) (any, error) { return ParseMatchPerformance(r) }},
+		{"player", "player.html", func(r io.Reader) (any, error) { return ParsePlayer(r) }},
+		{"player_matches", "player_matches.html", func(r io.Reader) (any, error) { return ParseMatchHistory(r) }},
+		{"team", "team.html", func(r io.Reader) (any, error) { return ParseTeam(r) }},
+		{"team_matches", "team_matches.html", func(r io.Reader) (any, error) { return ParseMatchHistory(r) }},
+		{"team_rating_history", "team_matches.html", func(r io.Reader) (any, error) { return ParseTeamRatingHistory(r) }},
+		{"event", "event.html", func(r io.Reader) (any, error) { return ParseEventDetail(r) }},
+		{"event_matches", "event_matches.html", func(r io.Reader) (any, error) {
+			schedule, results, err := ParseEventMatches(r)
+			return eventMatches{schedule, results}, err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			v, err := tt.parse(f)
+			if err != nil {
+				t.Fatalf("parse %s: %v", tt.fixture, err)
+			}
+			got, err := json.MarshalIndent(v, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+			checkGolden(t, filepath.Join("testdata", tt.name+".golden.json"), got)
+		})
+	}
+}
+
+// checkGolden compares got with the golden file at path, or rewrites it
+// when -update is set.
+func checkGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if bytes.Equal(got, want) {
+		return
+	}
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+	for i := range max(len(gotLines), len(wantLines)) {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			t.Fatalf("%s differs at line %d:\n got: %s\nwant: %s", path, i+1, g, w)
+		}
+	}
+}
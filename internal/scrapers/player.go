@@ -0,0 +1,285 @@
+package scrapers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gofiber/fiber/v2"
+)
+
+// playerTimespans are the windows vlr.gg offers for player agent stats.
+var playerTimespans = []string{"30d", "60d", "90d", "all"}
+
+//
+// VlrPlayer godoc
+// @Summary      Get a player profile
+// @Description  Returns a player's name, country, socials, current and past teams, agent stats over a timespan and recent matches
+// @Tags         players
+// @Produce      json
+// @Param        id        path      int     true   "vlr.gg player ID"
+// @Param        timespan  query     string  false  "Agent stats window: 30d, 60d, 90d (default) or all"
+// @Success      200  {object}  models.SegmentsResponse[models.PlayerProfile]
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /vlr/player/{id} [get]
+//
+func VlrPlayer(c *fiber.Ctx) error {
+	playerID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || playerID <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid player id"})
+	}
+	timespan := strings.ToLower(c.Query("timespan", "90d"))
+	if !slices.Contains(playerTimespans, timespan) {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid timespan, expected one of: " + strings.Join(playerTimespans, ", ")})
+	}
+
+	resp, err := fetch.Get(c.UserContext(), fmt.Sprintf("%s/player/%d/?timespan=%s", utils.BaseURL(), playerID, timespan))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch player"})
+	}
+	if resp.StatusCode == fiber.StatusNotFound {
+		return c.Status(404).JSON(fiber.Map{"error": "Player not found"})
+	}
+	profile, err := ParsePlayer(bytes.NewReader(resp.Body))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+	}
+	if profile.PlayerID == 0 {
+		profile.PlayerID = playerID
+	}
+	profile.Timespan = timespan
+
+	// The profile only previews a few results; the matches page has the
+	// most recent page of history.
+	matchesResp, err := fetch.Get(c.UserContext(), fmt.Sprintf("%s/player/matches/%d/", utils.BaseURL(), playerID))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch player matches"})
+	}
+	matches, err := ParseMatchHistory(bytes.NewReader(matchesResp.Body))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+	}
+	profile.RecentMatches = matches
+
+	return c.JSON(models.NewSegments(resp.StatusCode, []models.PlayerProfile{profile}))
+}
+
+// ParsePlayer extracts the header, team history and agent table from a
+// vlr.gg player page. RecentMatches is left empty; see ParseMatchHistory.
+func ParsePlayer(r io.Reader) (models.PlayerProfile, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return models.PlayerProfile{}, err
+	}
+
+	var p models.PlayerProfile
+	canonical := doc.Find("link[rel='canonical']").AttrOr("href", "")
+	p.PlayerID, p.Slug = utils.EntityID(canonical, "player")
+	p.URL = utils.AbsoluteURL(canonical)
+
+	header := doc.Find(".player-header")
+	p.Name = cleanText(header.Find("h1.wf-title").Text())
+	p.RealName = cleanText(header.Find(".player-real-name").Text())
+	p.Avatar = utils.AbsoluteURL(doc.Find(".wf-avatar img").AttrOr("src", ""))
+	flag := header.Find("i.flag").First()
+	p.Country = country(flag.AttrOr("class", ""), flag.Parent().Text())
+	p.Socials = parseSocials(header.Find("a[href^='http']"))
+
+	doc.Find("h2.wf-label.mod-large").Each(func(_ int, label *goquery.Selection) {
+		card := label.NextFiltered(".wf-card")
+		switch strings.ToLower(cleanText(label.Text())) {
+		case "current teams":
+			p.CurrentTeams = parseTeamStints(card)
+		case "past teams":
+			p.PastTeams = parseTeamStints(card)
+		}
+	})
+
+	p.Agents = parseAgentTable(doc.Find("table.wf-table").First())
+	return p, nil
+}
+
+// parseSocials maps external header links to platforms by host.
+func parseSocials(links *goquery.Selection) []models.SocialLink {
+	var socials []models.SocialLink
+	links.Each(func(_ int, a *goquery.Selection) {
+		href := a.AttrOr("href", "")
+		u, err := url.Parse(href)
+		if err != nil || u.Host == "" {
+			return
+		}
+		host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+		platform := strings.Split(host, ".")[0]
+		switch host {
+		case "x.com", "twitter.com":
+			platform = "twitter"
+		case "youtu.be":
+			platform = "youtube"
+		}
+		socials = append(socials, models.SocialLink{
+			Platform: platform,
+			Handle:   cleanText(a.Text()),
+			URL:      href,
+		})
+	})
+	return socials
+}
+
+// parseTeamStints reads the team items of a "Current Teams" or "Past Teams"
+// card. The light text holds the dates: "joined in March 2021", "left in
+// January 2023" or "March 2019 – January 2021", optionally after a role
+// such as "Inactive" or "Sub" on its own line.
+func parseTeamStints(card *goquery.Selection) []models.TeamStint {
+	var stints []models.TeamStint
+	card.Find("a.wf-module-item").Each(func(_ int, a *goquery.Selection) {
+		stint := models.TeamStint{
+			Name: cleanText(a.Find("div[style*='font-weight: 500']").First().Text()),
+			Logo: utils.AbsoluteURL(a.Find("img").AttrOr("src", "")),
+		}
+		stint.TeamID, stint.Slug = utils.EntityID(a.AttrOr("href", ""), "team")
+
+		var dates string
+		a.Find(".ge-text-light").Each(func(_ int, s *goquery.Selection) {
+			text := cleanText(s.Text())
+			lower := strings.ToLower(text)
+			switch {
+			case strings.HasPrefix(lower, "joined in "):
+				dates = text
+				stint.Joined = parseDate(text[len("joined in "):])
+			case strings.HasPrefix(lower, "left in "):
+				dates = text
+				stint.Left = parseDate(text[len("left in "):])
+			case strings.ContainsAny(text, "–-"):
+				dates = text
+				from, to, _ := strings.Cut(strings.ReplaceAll(text, "–", "-"), "-")
+				stint.Joined, stint.Left = parseDate(from), parseDate(to)
+			case text != "":
+				stint.Role = text
+			}
+		})
+		stint.Raw = rawStrings("dates", dates)
+		stints = append(stints, stint)
+	})
+	return stints
+}
+
+// parseAgentTable reads a player's agent table. Columns are matched by
+// header (Use, RND, Rating, ACS, K:D, ADR, KAST, KPR, APR, FKPR, FDPR, K, D,
+// A, FK, FD); the Use cell reads "(12) 34%".
+func parseAgentTable(table *goquery.Selection) []models.AgentStatLine {
+	var labels []string
+	table.Find("thead th").Each(func(_ int, th *goquery.Selection) {
+		labels = append(labels, strings.ToUpper(cleanText(th.Text())))
+	})
+
+	var agents []models.AgentStatLine
+	table.Find("tbody tr").Each(func(_ int, row *goquery.Selection) {
+		img := row.Find("td img").First()
+		line := models.AgentStatLine{Agent: img.AttrOr("alt", img.AttrOr("title", ""))}
+		raw := []string{}
+		row.Find("td").Each(func(i int, td *goquery.Selection) {
+			if i == 0 || i >= len(labels) {
+				return
+			}
+			text := cleanText(td.Text())
+			switch labels[i] {
+			case "USE":
+				count, pct, _ := strings.Cut(text, ")")
+				line.UsageCount = utils.ParseInt(strings.TrimPrefix(count, "("))
+				line.UsagePercentage = utils.ParseFloat(pct)
+				raw = append(raw, "usage", text)
+			case "RND":
+				line.RoundsPlayed = utils.ParseInt(text)
+			case "RATING":
+				line.Rating = utils.ParseFloat(text)
+			case "ACS":
+				line.AverageCombatScore = utils.ParseFloat(text)
+			case "K:D":
+				line.KillDeaths = utils.ParseFloat(text)
+			case "ADR":
+				line.AverageDamagePerRound = utils.ParseFloat(text)
+			case "KAST":
+				line.KillAssistsSurvivedTraded = utils.ParseFloat(text)
+			case "KPR":
+				line.KillsPerRound = utils.ParseFloat(text)
+			case "APR":
+				line.AssistsPerRound = utils.ParseFloat(text)
+			case "FKPR":
+				line.FirstKillsPerRound = utils.ParseFloat(text)
+			case "FDPR":
+				line.FirstDeathsPerRound = utils.ParseFloat(text)
+			case "K":
+				line.Kills = utils.ParseInt(text)
+			case "D":
+				line.Deaths = utils.ParseInt(text)
+			case "A":
+				line.Assists = utils.ParseInt(text)
+			case "FK":
+				line.FirstKills = utils.ParseInt(text)
+			case "FD":
+				line.FirstDeaths = utils.ParseInt(text)
+			}
+		})
+		line.Raw = rawStrings(raw...)
+		agents = append(agents, line)
+	})
+	return agents
+}
+
+// ParseMatchHistory extracts the match cards of a vlr.gg player or team
+// matches page (/player/matches/{id}, /team/matches/{id}).
+func ParseMatchHistory(r io.Reader) ([]models.RecentMatch, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.RecentMatch
+	doc.Find("a.m-item").Each(func(_ int, a *goquery.Selection) {
+		href := a.AttrOr("href", "")
+		m := models.RecentMatch{MatchPage: utils.AbsoluteURL(href)}
+		m.MatchID, m.MatchSlug = utils.EntityID(href, "")
+
+		event := a.Find(".m-item-event")
+		m.Event = cleanText(event.Find("div").First().Text())
+		series := event.Clone()
+		series.Find("div").Remove()
+		m.Series = cleanText(series.Text())
+
+		teams := a.Find(".m-item-team")
+		m.Team1 = cleanText(teams.Eq(0).Find(".m-item-team-name").Text())
+		m.Team2 = cleanText(teams.Eq(1).Find(".m-item-team-name").Text())
+
+		outcome := a.Find(".m-item-result")
+		scores := outcome.Find("span")
+		score1, score2 := cleanText(scores.Eq(0).Text()), cleanText(scores.Eq(1).Text())
+		m.Score1, m.Score2 = utils.ParseInt(score1), utils.ParseInt(score2)
+		switch {
+		case outcome.HasClass("mod-win"):
+			m.Result = "win"
+		case outcome.HasClass("mod-loss"):
+			m.Result = "loss"
+		}
+
+		date := cleanText(a.Find(".m-item-date div").First().Text())
+		m.Date = parseDate(date)
+		m.Raw = rawStrings(
+			"score1", score1,
+			"score2", score2,
+			"date", date,
+		)
+		result = append(result, m)
+	})
+	return result, nil
+}
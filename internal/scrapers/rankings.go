@@ -1,59 +1,114 @@
 package scrapers
 
 import (
-	"net/http"
+	"bytes"
+	"context"
+	"io"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gofiber/fiber/v2"
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/refresh"
+	"vlrggapi/internal/regions"
 	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
 )
 
 //
 // VlrRankings godoc
 // @Summary      Get Valorant team rankings
-// @Description  Returns team rankings for a given region
+// @Description  Returns team rankings for a given region, served from the background-refreshed store
 // @Tags         rankings
 // @Produce      json
-// @Param        region  query     string  true   "Region key (e.g. na, eu, ap, la, oce, kr, mn, gc, br, cn, jp, col)"
-// @Success      200  {object}  map[string]interface{}
-// @Failure      400  {object}  map[string]string
-// @Failure      500  {object}  map[string]string
+// @Param        region  query     string  true   "Region key (na, eu, br, ap, kr, cn, jp, la, la-s, la-n, oce, mn, gc, col) or rankings slug (e.g. north-america)"
+// @Success      200  {object}  models.RankingsResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
 // @Router       /vlr/rankings [get]
 //
 func VlrRankings(c *fiber.Ctx) error {
-	regionKey := c.Query("region")
-	regionMap := utils.Region
-	regionVal, ok := regionMap[regionKey]
+	return rankingsScraper.serve(c)
+}
+
+var rankingsScraper = &storedScraper[models.RankingsResponse]{
+	name:        "rankings",
+	route:       "/rankings",
+	description: "Team rankings per region",
+	interval:    refresh.Interval("rankings", time.Hour),
+	policy:      cache.PolicyFromEnv("rankings", cache.Policy{TTL: 30 * time.Minute, Stale: time.Hour}),
+	variants:    rankingsVariants(),
+	key:         rankingsKey,
+	scrape:      scrapeRankings,
+	stamp: func(resp models.RankingsResponse, f models.Freshness) models.RankingsResponse {
+		resp.Freshness = f
+		return resp
+	},
+}
+
+func init() {
+	RegisterScraper(rankingsScraper)
+}
+
+// rankingsVariants pre-warms every region.
+func rankingsVariants() []string {
+	variants := make([]string, len(regions.All))
+	for i, r := range regions.All {
+		variants[i] = "region=" + r.Key
+	}
+	return variants
+}
+
+func rankingsKey(q map[string]string) (string, error) {
+	region, ok := regions.Lookup(q["region"])
 	if !ok {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid region"})
+		return "", &httpError{400, "Invalid region", nil}
 	}
-	url := "https://www.vlr.gg/rankings/" + regionVal
+	return "region=" + region.Key, nil
+}
+
+func scrapeRankings(ctx context.Context, q map[string]string) (models.RankingsResponse, error) {
+	region, ok := regions.Lookup(q["region"])
+	if !ok {
+		return models.RankingsResponse{}, &httpError{400, "Invalid region", nil}
+	}
+	url := utils.BaseURL() + "/rankings/" + region.RankingsSlug
 
-	req, _ := http.NewRequest("GET", url, nil)
-	for k, v := range utils.Headers {
-		req.Header.Set(k, v)
+	resp, err := fetch.Get(ctx, url)
+	if err != nil {
+		return models.RankingsResponse{}, &httpError{500, "Failed to fetch rankings", err}
 	}
-	resp, err := http.DefaultClient.Do(req)
+
+	result, err := ParseRankings(bytes.NewReader(resp.Body))
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch rankings"})
+		return models.RankingsResponse{}, &httpError{500, "Failed to parse HTML", err}
 	}
-	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	return models.RankingsResponse{Status: resp.StatusCode, Data: result}, nil
+}
+
+// ParseRankings extracts the team rows from a vlr.gg /rankings/{region} page.
+func ParseRankings(r io.Reader) ([]models.Ranking, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+		return nil, err
 	}
 
-	var result []map[string]interface{}
+	now := clock()
+	var result []models.Ranking
 	doc.Find("div.rank-item").Each(func(i int, s *goquery.Selection) {
 		rank := strings.TrimSpace(s.Find("div.rank-item-rank-num").Text())
+		teamHref := s.Find("a.rank-item-team").AttrOr("href", "")
+		teamID, teamSlug := utils.EntityID(teamHref, "team")
+		lastMatchID, _ := utils.EntityID(s.Find("a.rank-item-last").AttrOr("href", ""), "")
 		team := strings.Split(s.Find("div.ge-text").Text(), "#")[0]
 		logo := s.Find("a.rank-item-team").Find("img").AttrOr("src", "")
 		re := regexp.MustCompile(`/img/vlr/tmp/vlr.png`)
 		logo = re.ReplaceAllString(logo, "")
-		country := s.Find("div.rank-item-team-country").Text()
+		countryName := s.Find("div.rank-item-team-country").Text()
 		lastPlayed := strings.Split(strings.ReplaceAll(strings.ReplaceAll(s.Find("a.rank-item-last").Text(), "\n", ""), "\t", ""), "v")[0]
 		lastPlayedTeamRaw := strings.ReplaceAll(strings.ReplaceAll(s.Find("a.rank-item-last").Text(), "\t", ""), "\n", "")
 		lastPlayedTeamParts := strings.SplitN(lastPlayedTeamRaw, "o", 2)
@@ -65,18 +120,29 @@ func VlrRankings(c *fiber.Ctx) error {
 		record := strings.ReplaceAll(strings.ReplaceAll(s.Find("div.rank-item-record").Text(), "\t", ""), "\n", "")
 		earnings := strings.ReplaceAll(strings.ReplaceAll(s.Find("div.rank-item-earnings").Text(), "\t", ""), "\n", "")
 
-		result = append(result, map[string]interface{}{
-			"rank":                rank,
-			"team":                strings.TrimSpace(team),
-			"country":             country,
-			"last_played":         strings.TrimSpace(lastPlayed),
-			"last_played_team":    strings.TrimSpace(lastPlayedTeamStr),
-			"last_played_team_logo": lastPlayedTeamLogo,
-			"record":              record,
-			"earnings":            earnings,
-			"logo":                logo,
+		result = append(result, models.Ranking{
+			Rank:               utils.ParseInt(rank),
+			Team:               strings.TrimSpace(team),
+			TeamID:             teamID,
+			TeamSlug:           teamSlug,
+			TeamURL:            utils.AbsoluteURL(teamHref),
+			Country:            country("", countryName),
+			LastPlayed:         parseRelativeTime(lastPlayed, now),
+			LastPlayedTeam:     strings.TrimSpace(lastPlayedTeamStr),
+			LastPlayedTeamLogo: lastPlayedTeamLogo,
+			LastPlayedMatchID:  lastMatchID,
+			Record:             parseRecord(record),
+			Earnings:           parseMoney(earnings),
+			Logo:               logo,
+			Raw: rawStrings(
+				"rank", rank,
+				"last_played", lastPlayed,
+				"record", record,
+				"earnings", earnings,
+				"country", countryName,
+			),
 		})
 	})
 
-	return c.JSON(fiber.Map{"status": resp.StatusCode, "data": result})
+	return result, nil
 }
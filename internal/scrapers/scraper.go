@@ -1,6 +1,9 @@
 package scrapers
 
 import (
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/refresh"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -11,6 +14,19 @@ type Scraper interface {
 	Description() string
 }
 
+// Refreshable is a Scraper the background refresher keeps warm in the store.
+type Refreshable interface {
+	Scraper
+	refresh.Job
+}
+
+// Cached is a Scraper that declares how long its responses may be cached.
+// Other scrapers use the cache's default policy.
+type Cached interface {
+	Scraper
+	CachePolicy() cache.Policy
+}
+
 // Registry holds all registered scrapers.
 var Registry = make([]Scraper, 0)
 
@@ -18,3 +34,14 @@ var Registry = make([]Scraper, 0)
 func RegisterScraper(s Scraper) {
 	Registry = append(Registry, s)
 }
+
+// Refreshables returns the registered scrapers the refresher should run.
+func Refreshables() []refresh.Job {
+	var jobs []refresh.Job
+	for _, s := range Registry {
+		if r, ok := s.(Refreshable); ok {
+			jobs = append(jobs, r)
+		}
+	}
+	return jobs
+}
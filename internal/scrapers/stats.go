@@ -1,56 +1,150 @@
 package scrapers
 
 import (
+	"bytes"
+	"context"
 	"fmt"
-	"net/http"
+	"io"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gofiber/fiber/v2"
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/refresh"
 	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
 )
 
 //
 // VlrStats godoc
 // @Summary      Get Valorant player statistics
-// @Description  Returns player statistics, filterable by region and timespan
+// @Description  Returns player statistics, filterable by every filter of the vlr.gg stats page
 // @Tags         stats
 // @Produce      json
-// @Param        region    query     string  true   "Region key (e.g. na, eu, ap, la, oce, kr, mn, gc, br, cn, jp, col)"
-// @Param        timespan  query     string  false  "Timespan (e.g. all, 30 for 30 days)"
-// @Success      200  {object}  map[string]interface{}
-// @Failure      400  {object}  map[string]string
-// @Failure      500  {object}  map[string]string
+// @Param        region          query     string  false  "Region key (e.g. na, eu, ap, la, oce, kr, mn, gc, br, cn, jp, col) or all"  default(all)
+// @Param        country         query     string  false  "Two-letter country code or all"  default(all)
+// @Param        agent           query     string  false  "Agent name (e.g. jett, kayo) or all"  default(all)
+// @Param        map             query     string  false  "Map name (e.g. ascent, lotus) or all"
+// @Param        map_id          query     string  false  "vlr.gg map ID or all; cannot be combined with map"
+// @Param        event_group_id  query     string  false  "vlr.gg event group ID or all"  default(all)
+// @Param        event_id        query     string  false  "vlr.gg event ID or all"  default(all)
+// @Param        min_rounds      query     int     false  "Minimum rounds played"  default(200)
+// @Param        min_rating      query     int     false  "Minimum rating"  default(1550)
+// @Param        timespan        query     string  false  "Days (30, 60, 90) or all"  default(all)
+// @Param        date_start      query     string  false  "Start of a custom date range (YYYY-MM-DD); requires date_end"
+// @Param        date_end        query     string  false  "End of a custom date range (YYYY-MM-DD); requires date_start"
+// @Success      200  {object}  models.SegmentsResponse[models.PlayerStatLine]
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
 // @Router       /vlr/stats [get]
 //
 func VlrStats(c *fiber.Ctx) error {
-	region := c.Query("region")
-	timespan := c.Query("timespan")
-
-	baseURL := fmt.Sprintf("https://www.vlr.gg/stats/?event_group_id=all&event_id=all&region=%s&country=all&min_rounds=200&min_rating=1550&agent=all&map_id=all", region)
-	var url string
-	if strings.ToLower(timespan) == "all" {
-		url = baseURL + "&timespan=all"
-	} else {
-		url = baseURL + "&timespan=" + timespan + "d"
+	return statsScraper.serve(c)
+}
+
+var statsScraper = &storedScraper[models.SegmentsResponse[models.PlayerStatLine]]{
+	name:        "stats",
+	route:       "/stats",
+	description: "Player statistics with vlr.gg stats page filters",
+	interval:    refresh.Interval("stats", 30*time.Minute),
+	policy:      cache.PolicyFromEnv("stats", cache.Policy{TTL: 10 * time.Minute, Stale: 30 * time.Minute}),
+	variants:    []string{""},
+	key:         statsKey,
+	scrape:      scrapeStats,
+	stamp:       stampSegments[models.PlayerStatLine],
+}
+
+func init() {
+	RegisterScraper(statsScraper)
+}
+
+// statsKey identifies a request by the vlr.gg URL its filters resolve to.
+func statsKey(q map[string]string) (string, error) {
+	filter, err := ParseStatsFilter(q, DefaultStatsFilter())
+	if err != nil {
+		return "", &httpError{400, userMessage(err), nil}
 	}
+	return filter.URL(), nil
+}
 
-	req, _ := http.NewRequest("GET", url, nil)
-	for k, v := range utils.Headers {
-		req.Header.Set(k, v)
+func scrapeStats(ctx context.Context, q map[string]string) (models.SegmentsResponse[models.PlayerStatLine], error) {
+	filter, err := ParseStatsFilter(q, DefaultStatsFilter())
+	if err != nil {
+		return models.SegmentsResponse[models.PlayerStatLine]{}, &httpError{400, userMessage(err), nil}
 	}
-	resp, err := http.DefaultClient.Do(req)
+	url := filter.URL()
+
+	resp, err := fetch.Get(ctx, url)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch stats"})
+		return models.SegmentsResponse[models.PlayerStatLine]{}, &httpError{500, "Failed to fetch stats", err}
+	}
+	result, err := ParseStats(bytes.NewReader(resp.Body))
+	if err != nil {
+		return models.SegmentsResponse[models.PlayerStatLine]{}, &httpError{500, "Failed to parse HTML", err}
+	}
+
+	return models.NewSegments(resp.StatusCode, result), nil
+}
+
+//
+// VlrEventStats godoc
+// @Summary      Get player statistics for an event
+// @Description  Returns the player stats table scoped to one event, with the same rows and filters as /vlr/stats but no minimum rounds or rating by default. An event_id query parameter naming a different event is rejected with 400.
+// @Tags         stats
+// @Produce      json
+// @Param        id          path      int     true   "vlr.gg event ID"
+// @Param        region      query     string  false  "Region key or all"  default(all)
+// @Param        agent       query     string  false  "Agent name or all"  default(all)
+// @Param        map         query     string  false  "Map name or all"
+// @Param        min_rounds  query     int     false  "Minimum rounds played"  default(0)
+// @Param        min_rating  query     int     false  "Minimum rating"  default(0)
+// @Success      200  {object}  models.SegmentsResponse[models.PlayerStatLine]
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /vlr/event/{id}/stats [get]
+//
+func VlrEventStats(c *fiber.Ctx) error {
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || eventID <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid event id"})
 	}
-	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	// The event comes from the path; a different event_id would be ignored
+	q := c.Queries()
+	if v := strings.TrimSpace(q["event_id"]); v != "" && v != strconv.Itoa(eventID) {
+		return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("Query parameter event_id %q conflicts with event %d in the path", v, eventID)})
+	}
+	base := DefaultStatsFilter()
+	base.MinRounds, base.MinRating = 0, 0
+	filter, err := ParseStatsFilter(q, base)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": userMessage(err)})
+	}
+	filter.EventID = strconv.Itoa(eventID)
+
+	resp, err := fetch.Get(c.UserContext(), filter.URL())
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch stats"})
+	}
+	result, err := ParseStats(bytes.NewReader(resp.Body))
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
 	}
 
-	var result []map[string]interface{}
+	return c.JSON(models.NewSegments(resp.StatusCode, result))
+}
+
+// ParseStats extracts the player rows from a vlr.gg /stats page.
+func ParseStats(r io.Reader) ([]models.PlayerStatLine, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.PlayerStatLine
 	doc.Find("tbody tr").Each(func(i int, s *goquery.Selection) {
 		player := strings.Fields(strings.ReplaceAll(strings.ReplaceAll(s.Text(), "\t", ""), "\n", " "))
 		playerName := ""
@@ -62,6 +156,9 @@ func VlrStats(c *fiber.Ctx) error {
 			org = player[1]
 		}
 
+		playerID, playerSlug := utils.EntityID(s.Find("a[href*='/player/']").AttrOr("href", ""), "player")
+		teamID, _ := utils.EntityID(s.Find("a[href*='/team/']").AttrOr("href", ""), "team")
+
 		var agents []string
 		s.Find("td.mod-agents img").Each(func(_ int, img *goquery.Selection) {
 			src, _ := img.Attr("src")
@@ -84,24 +181,41 @@ func VlrStats(c *fiber.Ctx) error {
 			colorSq = append(colorSq, "")
 		}
 
-		result = append(result, map[string]interface{}{
-			"player":                     playerName,
-			"org":                        org,
-			"agents":                     agents,
-			"rounds_played":              rnd,
-			"rating":                     colorSq[0],
-			"average_combat_score":       colorSq[1],
-			"kill_deaths":                colorSq[2],
-			"kill_assists_survived_traded": colorSq[3],
-			"average_damage_per_round":   colorSq[4],
-			"kills_per_round":            colorSq[5],
-			"assists_per_round":          colorSq[6],
-			"first_kills_per_round":      colorSq[7],
-			"first_deaths_per_round":     colorSq[8],
-			"headshot_percentage":        colorSq[9],
-			"clutch_success_percentage":  colorSq[10],
+		result = append(result, models.PlayerStatLine{
+			Player:                    playerName,
+			PlayerID:                  playerID,
+			PlayerSlug:                playerSlug,
+			Org:                       org,
+			TeamID:                    teamID,
+			Agents:                    agents,
+			RoundsPlayed:              utils.ParseInt(rnd),
+			Rating:                    utils.ParseFloat(colorSq[0]),
+			AverageCombatScore:        utils.ParseFloat(colorSq[1]),
+			KillDeaths:                utils.ParseFloat(colorSq[2]),
+			KillAssistsSurvivedTraded: utils.ParseFloat(colorSq[3]),
+			AverageDamagePerRound:     utils.ParseFloat(colorSq[4]),
+			KillsPerRound:             utils.ParseFloat(colorSq[5]),
+			AssistsPerRound:           utils.ParseFloat(colorSq[6]),
+			FirstKillsPerRound:        utils.ParseFloat(colorSq[7]),
+			FirstDeathsPerRound:       utils.ParseFloat(colorSq[8]),
+			HeadshotPercentage:        utils.ParseFloat(colorSq[9]),
+			ClutchSuccessPercentage:   utils.ParseFloat(colorSq[10]),
+			Raw: rawStrings(
+				"rounds_played", rnd,
+				"rating", colorSq[0],
+				"average_combat_score", colorSq[1],
+				"kill_deaths", colorSq[2],
+				"kill_assists_survived_traded", colorSq[3],
+				"average_damage_per_round", colorSq[4],
+				"kills_per_round", colorSq[5],
+				"assists_per_round", colorSq[6],
+				"first_kills_per_round", colorSq[7],
+				"first_deaths_per_round", colorSq[8],
+				"headshot_percentage", colorSq[9],
+				"clutch_success_percentage", colorSq[10],
+			),
 		})
 	})
 
-	return c.JSON(fiber.Map{"data": fiber.Map{"status": resp.StatusCode, "segments": result}})
+	return result, nil
 }
@@ -0,0 +1,198 @@
+package scrapers
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"vlrggapi/internal/regions"
+	"vlrggapi/internal/utils"
+)
+
+// StatsFilter holds every filter of the vlr.gg /stats page. String fields
+// use "all" for no filter.
+type StatsFilter struct {
+	EventGroupID string
+	EventID      string
+	Region       string
+	Country      string
+	Agent        string
+	MapID        string
+	MinRounds    int
+	MinRating    int
+	// Timespan is "all" or a day count such as "30d". It is ignored by
+	// vlr.gg when DateStart and DateEnd are set.
+	Timespan  string
+	DateStart string
+	DateEnd   string
+}
+
+// DefaultStatsFilter is the global table as /vlr/stats has always returned
+// it: every region and agent, at least 200 rounds and 1550 rating.
+func DefaultStatsFilter() StatsFilter {
+	return StatsFilter{
+		EventGroupID: "all",
+		EventID:      "all",
+		Region:       "all",
+		Country:      "all",
+		Agent:        "all",
+		MapID:        "all",
+		MinRounds:    200,
+		MinRating:    1550,
+		Timespan:     "all",
+	}
+}
+
+// URL builds the vlr.gg /stats URL for the filter.
+func (f StatsFilter) URL() string {
+	q := url.Values{}
+	q.Set("event_group_id", f.EventGroupID)
+	q.Set("event_id", f.EventID)
+	q.Set("region", f.Region)
+	q.Set("country", f.Country)
+	q.Set("min_rounds", strconv.Itoa(f.MinRounds))
+	q.Set("min_rating", strconv.Itoa(f.MinRating))
+	q.Set("agent", f.Agent)
+	q.Set("map_id", f.MapID)
+	q.Set("timespan", f.Timespan)
+	if f.DateStart != "" {
+		q.Set("date_start", f.DateStart)
+		q.Set("date_end", f.DateEnd)
+	}
+	return utils.BaseURL() + "/stats/?" + q.Encode()
+}
+
+// statsAgents are the agent values vlr.gg accepts, i.e. agent names in
+// lowercase without punctuation.
+var statsAgents = []string{
+	"astra", "breach", "brimstone", "chamber", "clove", "cypher", "deadlock",
+	"fade", "gekko", "harbor", "iso", "jett", "kayo", "killjoy", "neon",
+	"omen", "phoenix", "raze", "reyna", "sage", "skye", "sova", "tejo",
+	"viper", "vyse", "waylay", "yoru",
+}
+
+// statsMaps maps map names to the map_id values of the vlr.gg stats page.
+var statsMaps = map[string]string{
+	"bind":     "1",
+	"haven":    "2",
+	"split":    "3",
+	"ascent":   "5",
+	"icebox":   "6",
+	"breeze":   "8",
+	"fracture": "9",
+	"pearl":    "10",
+	"lotus":    "11",
+	"sunset":   "12",
+	"abyss":    "13",
+}
+
+// ParseStatsFilter validates the /vlr/stats query parameters on top of
+// base. Unknown values return an error suitable for a 400 response.
+func ParseStatsFilter(query map[string]string, base StatsFilter) (StatsFilter, error) {
+	f := base
+	get := func(key string) string { return strings.ToLower(strings.TrimSpace(query[key])) }
+
+	if v := get("region"); v != "" && v != "all" {
+		region, ok := regions.Lookup(v)
+		if !ok {
+			return f, fmt.Errorf("invalid region %q, expected one of: %s, all", v, strings.Join(regions.Keys(), ", "))
+		}
+		f.Region = region.StatsSlug
+	}
+	if v := get("country"); v != "" && v != "all" {
+		c, ok := regions.CountryByCode(v)
+		if !ok {
+			return f, fmt.Errorf("invalid country %q, expected an ISO 3166-1 alpha-2 code", v)
+		}
+		f.Country = strings.ToLower(c.Code)
+	}
+	if v := get("agent"); v != "" {
+		v = strings.NewReplacer("/", "", "-", "", " ", "").Replace(v)
+		if !slices.Contains(statsAgents, v) && v != "all" {
+			return f, fmt.Errorf("invalid agent %q", v)
+		}
+		f.Agent = v
+	}
+	if get("map") != "" && get("map_id") != "" {
+		return f, fmt.Errorf("map and map_id cannot be combined")
+	}
+	if v := get("map"); v != "" {
+		id, ok := statsMaps[v]
+		if !ok && v != "all" {
+			return f, fmt.Errorf("invalid map %q", v)
+		}
+		if ok {
+			f.MapID = id
+		} else {
+			f.MapID = "all"
+		}
+	}
+	for _, p := range []struct {
+		key string
+		dst *string
+	}{
+		{"map_id", &f.MapID},
+		{"event_group_id", &f.EventGroupID},
+		{"event_id", &f.EventID},
+	} {
+		if v := get(p.key); v != "" {
+			if n, err := strconv.Atoi(v); (err != nil || n <= 0) && v != "all" {
+				return f, fmt.Errorf("invalid %s %q, expected a positive integer or all", p.key, v)
+			}
+			*p.dst = v
+		}
+	}
+	for _, p := range []struct {
+		key string
+		dst *int
+	}{
+		{"min_rounds", &f.MinRounds},
+		{"min_rating", &f.MinRating},
+	} {
+		if v := get(p.key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return f, fmt.Errorf("invalid %s %q, expected a non-negative integer", p.key, v)
+			}
+			*p.dst = n
+		}
+	}
+
+	if v := get("timespan"); v != "" {
+		switch strings.TrimSuffix(v, "d") {
+		case "30", "60", "90":
+			f.Timespan = strings.TrimSuffix(v, "d") + "d"
+		case "all":
+			f.Timespan = "all"
+		default:
+			return f, fmt.Errorf("invalid timespan %q, expected one of: 30, 60, 90, all", v)
+		}
+	}
+
+	start, end := get("date_start"), get("date_end")
+	if start != "" || end != "" {
+		if start == "" || end == "" {
+			return f, fmt.Errorf("date_start and date_end must be set together")
+		}
+		from, err := time.Parse(time.DateOnly, start)
+		if err != nil {
+			return f, fmt.Errorf("invalid date_start %q, expected YYYY-MM-DD", start)
+		}
+		to, err := time.Parse(time.DateOnly, end)
+		if err != nil {
+			return f, fmt.Errorf("invalid date_end %q, expected YYYY-MM-DD", end)
+		}
+		if to.Before(from) {
+			return f, fmt.Errorf("date_end must not be before date_start")
+		}
+		if get("timespan") != "" {
+			return f, fmt.Errorf("timespan cannot be combined with date_start and date_end")
+		}
+		f.DateStart, f.DateEnd = start, end
+		f.Timespan = "all"
+	}
+	return f, nil
+}
@@ -0,0 +1,138 @@
+package scrapers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"vlrggapi/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// statsURL is the /stats URL of the default filter with the given query
+// values replaced, as key, value pairs.
+func statsURL(kv ...string) string {
+	q := url.Values{
+		"event_group_id": {"all"},
+		"event_id":       {"all"},
+		"region":         {"all"},
+		"country":        {"all"},
+		"agent":          {"all"},
+		"map_id":         {"all"},
+		"min_rounds":     {"200"},
+		"min_rating":     {"1550"},
+		"timespan":       {"all"},
+	}
+	for i := 0; i < len(kv); i += 2 {
+		q.Set(kv[i], kv[i+1])
+	}
+	return utils.BaseURL() + "/stats/?" + q.Encode()
+}
+
+func TestParseStatsFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   map[string]string
+		want    string
+		wantErr string
+	}{
+		{"defaults", nil, statsURL(), ""},
+		{"all is no filter", map[string]string{"region": "all", "country": "ALL", "agent": "all", "map": "all", "timespan": "all"}, statsURL(), ""},
+
+		{"region key", map[string]string{"region": "EU"}, statsURL("region", "eu"), ""},
+		{"region rankings slug", map[string]string{"region": "north-america"}, statsURL("region", "na"), ""},
+		{"region name", map[string]string{"region": " Latin America South "}, statsURL("region", "la-s"), ""},
+		{"unknown region", map[string]string{"region": "mars"}, "", `invalid region "mars"`},
+
+		{"country", map[string]string{"country": "US"}, statsURL("country", "us"), ""},
+		{"unknown country", map[string]string{"country": "zz"}, "", `invalid country "zz"`},
+		{"country name", map[string]string{"country": "germany"}, "", `invalid country "germany"`},
+
+		{"agent", map[string]string{"agent": "Jett"}, statsURL("agent", "jett"), ""},
+		{"agent punctuation", map[string]string{"agent": "KAY/O"}, statsURL("agent", "kayo"), ""},
+		{"unknown agent", map[string]string{"agent": "bob"}, "", `invalid agent "bob"`},
+
+		{"map", map[string]string{"map": "Lotus"}, statsURL("map_id", "11"), ""},
+		{"map_id", map[string]string{"map_id": "5"}, statsURL("map_id", "5"), ""},
+		{"unknown map", map[string]string{"map": "narnia"}, "", `invalid map "narnia"`},
+		{"map and map_id", map[string]string{"map": "bind", "map_id": "1"}, "", "map and map_id cannot be combined"},
+		{"zero map_id", map[string]string{"map_id": "0"}, "", `invalid map_id "0", expected a positive integer or all`},
+
+		{"event", map[string]string{"event_id": "2283", "event_group_id": "all"}, statsURL("event_id", "2283"), ""},
+		{"event group", map[string]string{"event_group_id": "45"}, statsURL("event_group_id", "45"), ""},
+		{"non-numeric event", map[string]string{"event_id": "masters"}, "", `invalid event_id "masters"`},
+
+		{"min rounds and rating", map[string]string{"min_rounds": "0", "min_rating": "1800"}, statsURL("min_rounds", "0", "min_rating", "1800"), ""},
+		{"negative min rounds", map[string]string{"min_rounds": "-1"}, "", `invalid min_rounds "-1", expected a non-negative integer`},
+		{"non-numeric min rating", map[string]string{"min_rating": "high"}, "", `invalid min_rating "high"`},
+
+		{"timespan days", map[string]string{"timespan": "30"}, statsURL("timespan", "30d"), ""},
+		{"timespan with suffix", map[string]string{"timespan": "90D"}, statsURL("timespan", "90d"), ""},
+		{"unknown timespan", map[string]string{"timespan": "7d"}, "", `invalid timespan "7d"`},
+
+		{"date range", map[string]string{"date_start": "2026-01-01", "date_end": "2026-06-30"},
+			statsURL("date_start", "2026-01-01", "date_end", "2026-06-30"), ""},
+		{"single day", map[string]string{"date_start": "2026-06-30", "date_end": "2026-06-30"},
+			statsURL("date_start", "2026-06-30", "date_end", "2026-06-30"), ""},
+		{"date start only", map[string]string{"date_start": "2026-01-01"}, "", "date_start and date_end must be set together"},
+		{"bad date", map[string]string{"date_start": "01/01/2026", "date_end": "2026-06-30"}, "", `invalid date_start "01/01/2026", expected YYYY-MM-DD`},
+		{"reversed dates", map[string]string{"date_start": "2026-06-30", "date_end": "2026-01-01"}, "", "date_end must not be before date_start"},
+		{"dates and timespan", map[string]string{"date_start": "2026-01-01", "date_end": "2026-06-30", "timespan": "30"}, "",
+			"timespan cannot be combined with date_start and date_end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseStatsFilter(tt.query, DefaultStatsFilter())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.URL(); got != tt.want {
+				t.Errorf("URL =\n %s\nwant\n %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVlrEventStatsRejectsBadQueries(t *testing.T) {
+	app := fiber.New()
+	app.Get("/vlr/event/:id/stats", VlrEventStats)
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"/vlr/event/2283/stats?event_id=2284", `Query parameter event_id "2284" conflicts with event 2283 in the path`},
+		{"/vlr/event/2283/stats?event_id=all", `Query parameter event_id "all" conflicts with event 2283 in the path`},
+		{"/vlr/event/0/stats", "Invalid event id"},
+		{"/vlr/event/2283/stats?agent=bob", `Invalid agent "bob"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", tt.target, nil), -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			data, _ := io.ReadAll(resp.Body)
+			var body struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(data, &body); err != nil {
+				t.Fatalf("%s: %v", data, err)
+			}
+			if resp.StatusCode != 400 || body.Error != tt.want {
+				t.Errorf("%d %q, want 400 %q", resp.StatusCode, body.Error, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package scrapers
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"vlrggapi/internal/cache"
+	"vlrggapi/internal/store"
+	"vlrggapi/pkg/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// storedScraper is a Refreshable scraper whose responses are kept in the
+// store. The refresher scrapes its variants on every interval; requests for a
+// variant are answered from the store and only scrape themselves on a miss or
+// when the stored copy is more than two intervals old. Other queries are
+// scraped on every request and left to the HTTP cache, which keeps the store
+// bounded by the number of variants. E is the response envelope.
+type storedScraper[E any] struct {
+	name        string
+	route       string
+	description string
+	interval    time.Duration
+	variants    []string
+	// policy is how long the HTTP cache keeps responses.
+	policy cache.Policy
+	// key validates a request query and returns the canonical form of the
+	// parameters that change the response, so equivalent requests share an
+	// entry.
+	key func(q map[string]string) (string, error)
+	// scrape builds the response for a query that passed key.
+	scrape func(ctx context.Context, q map[string]string) (E, error)
+	// stamp returns resp with its freshness set.
+	stamp func(resp E, f models.Freshness) E
+}
+
+func (s *storedScraper[E]) Route() string             { return s.route }
+func (s *storedScraper[E]) Handler() fiber.Handler    { return s.serve }
+func (s *storedScraper[E]) Description() string       { return s.description }
+func (s *storedScraper[E]) Name() string              { return s.name }
+func (s *storedScraper[E]) Interval() time.Duration   { return s.interval }
+func (s *storedScraper[E]) Variants() []string        { return s.variants }
+func (s *storedScraper[E]) CachePolicy() cache.Policy { return s.policy }
+
+// Refresh scrapes one variant, given as a query string, into the store.
+func (s *storedScraper[E]) Refresh(ctx context.Context, variant string) error {
+	q, err := variantQuery(variant)
+	if err != nil {
+		return err
+	}
+	key, err := s.key(q)
+	if err != nil {
+		return err
+	}
+	_, err = s.fetch(ctx, key, q, true)
+	return err
+}
+
+func (s *storedScraper[E]) serve(c *fiber.Ctx) error {
+	q := c.Queries()
+	key, err := s.key(q)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	entry, ok := store.Default().Get(s.name + "?" + key)
+	if ok && s.purgedAfter(c.UserContext(), key, entry.FetchedAt) {
+		ok = false
+	}
+	if !ok || entry.Age(time.Now()) > 2*s.interval {
+		fresh, err := s.fetch(c.UserContext(), key, q, s.isVariant(key))
+		switch {
+		case err == nil:
+			entry, ok = fresh, true
+		case !ok:
+			return respondError(c, err)
+		}
+		// A failed re-scrape falls back to the old entry.
+	}
+	return c.JSON(s.stamp(entry.Value.(E), models.NewFreshness(entry.FetchedAt)))
+}
+
+// purgeMarkerPrefix starts the response cache keys of purge markers. Cached
+// responses are keyed by request URL, which always starts with a slash, so
+// the two never collide; the admin listing leaves markers out.
+const purgeMarkerPrefix = "purge:"
+
+// purgeKey is the response cache key recording when the scraper's stored
+// data for the canonical query key was last purged. The store is per
+// replica, so when the cache is shared a purge made through one replica
+// reaches the stored copies of all of them through these markers.
+func purgeKey(name, key string) string {
+	return purgeMarkerPrefix + name + "?" + key
+}
+
+// purge drops the stored data of the request query, or of every variant when
+// all is set, and reports whether any of it could have been stored. Only
+// pre-warmed variants are stored, so other queries have nothing to drop.
+// When the cache is shared, a marker for each dropped key is written for the
+// other replicas; it outlives any stored scrape that could still be served.
+func (s *storedScraper[E]) purge(ctx context.Context, responses cache.Cache, query string, all bool) (bool, error) {
+	var keys []string
+	if all {
+		for _, variant := range s.variants {
+			if q, err := variantQuery(variant); err == nil {
+				if key, err := s.key(q); err == nil {
+					keys = append(keys, key)
+				}
+			}
+		}
+	} else if q, err := variantQuery(query); err == nil {
+		if key, err := s.key(q); err == nil && s.isVariant(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	now := time.Now()
+	marker := cache.Entry{StoredAt: now, LastModified: now, TTL: 2 * s.interval}
+	for _, key := range keys {
+		if cache.Shared(responses) {
+			if err := responses.Set(ctx, purgeKey(s.name, key), marker, marker.TTL); err != nil {
+				return false, err
+			}
+		}
+		store.Default().Delete(s.name + "?" + key)
+	}
+	return len(keys) > 0, nil
+}
+
+// purgedAfter reports whether the stored data for the canonical query key
+// was purged on another replica after fetchedAt. Purges on this replica
+// delete the data directly, so an unshared cache is never asked. A cache
+// error counts as no purge.
+func (s *storedScraper[E]) purgedAfter(ctx context.Context, key string, fetchedAt time.Time) bool {
+	responses := cache.Default()
+	if responses == nil || !cache.Shared(responses) {
+		return false
+	}
+	marker, found, err := responses.Get(ctx, purgeKey(s.name, key))
+	return err == nil && found && marker.StoredAt.After(fetchedAt)
+}
+
+// fetch scrapes the query with canonical form key, storing the result when
+// keep is set.
+func (s *storedScraper[E]) fetch(ctx context.Context, key string, q map[string]string, keep bool) (store.Entry, error) {
+	resp, err := s.scrape(ctx, q)
+	if err != nil {
+		return store.Entry{}, err
+	}
+	entry := store.Entry{Value: resp, FetchedAt: time.Now()}
+	if keep {
+		store.Default().Set(s.name+"?"+key, entry)
+	}
+	return entry, nil
+}
+
+// isVariant reports whether key is the canonical form of one of the
+// pre-warmed variants.
+func (s *storedScraper[E]) isVariant(key string) bool {
+	for _, variant := range s.variants {
+		q, err := variantQuery(variant)
+		if err != nil {
+			continue
+		}
+		if vk, err := s.key(q); err == nil && vk == key {
+			return true
+		}
+	}
+	return false
+}
+
+// variantQuery parses a variant query string into the map handlers get from
+// c.Queries.
+func variantQuery(variant string) (map[string]string, error) {
+	values, err := url.ParseQuery(variant)
+	if err != nil {
+		return nil, err
+	}
+	q := make(map[string]string, len(values))
+	for k := range values {
+		q[k] = values.Get(k)
+	}
+	return q, nil
+}
+
+// noQuery is the key func of scrapers without parameters.
+func noQuery(map[string]string) (string, error) {
+	return "", nil
+}
+
+// stampSegments is the stamp func of scrapers answering with the standard
+// envelope.
+func stampSegments[T any](resp models.SegmentsResponse[T], f models.Freshness) models.SegmentsResponse[T] {
+	resp.Data.Freshness = f
+	return resp
+}
+
+// httpError is a scrape failure together with the status and message the
+// handler answers with.
+type httpError struct {
+	status  int
+	message string
+	err     error
+}
+
+func (e *httpError) Error() string {
+	if e.err == nil {
+		return e.message
+	}
+	return e.message + ": " + e.err.Error()
+}
+
+func (e *httpError) Unwrap() error {
+	return e.err
+}
+
+// userMessage turns a validation error into a 400 message: Go error strings
+// are lowercase, response messages are capitalised.
+func userMessage(err error) string {
+	msg := err.Error()
+	if msg == "" {
+		return msg
+	}
+	r, size := utf8.DecodeRuneInString(msg)
+	return string(unicode.ToUpper(r)) + msg[size:]
+}
+
+func respondError(c *fiber.Ctx, err error) error {
+	var he *httpError
+	if errors.As(err, &he) {
+		return c.Status(he.status).JSON(fiber.Map{"error": he.message})
+	}
+	return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+}
@@ -0,0 +1,208 @@
+package scrapers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"vlrggapi/internal/fetch"
+	"vlrggapi/internal/regions"
+	"vlrggapi/internal/utils"
+	"vlrggapi/pkg/models"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gofiber/fiber/v2"
+)
+
+//
+// VlrTeam godoc
+// @Summary      Get a team profile
+// @Description  Returns a team's roster with roles, region, current ranking and rating, rating history, total winnings, recent results and upcoming matches
+// @Tags         teams
+// @Produce      json
+// @Param        id   path      int  true  "vlr.gg team ID"
+// @Success      200  {object}  models.SegmentsResponse[models.TeamProfile]
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /vlr/team/{id} [get]
+//
+func VlrTeam(c *fiber.Ctx) error {
+	teamID, err := strconv.Atoi(c.Params("id"))
+	if err != nil || teamID <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid team id"})
+	}
+
+	resp, err := fetch.Get(c.UserContext(), fmt.Sprintf("%s/team/%d/", utils.BaseURL(), teamID))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch team"})
+	}
+	if resp.StatusCode == fiber.StatusNotFound {
+		return c.Status(404).JSON(fiber.Map{"error": "Team not found"})
+	}
+	team, err := ParseTeam(bytes.NewReader(resp.Body))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+	}
+	if team.TeamID == 0 {
+		team.TeamID = teamID
+	}
+
+	matchesResp, err := fetch.Get(c.UserContext(), fmt.Sprintf("%s/team/matches/%d/", utils.BaseURL(), teamID))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch team matches"})
+	}
+	matches, err := ParseMatchHistory(bytes.NewReader(matchesResp.Body))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+	}
+	for _, m := range matches {
+		if m.Score1 == nil && m.Score2 == nil {
+			team.UpcomingMatches = append(team.UpcomingMatches, m)
+		} else {
+			team.RecentResults = append(team.RecentResults, m)
+		}
+	}
+	team.RatingHistory, err = ParseTeamRatingHistory(bytes.NewReader(matchesResp.Body))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to parse HTML"})
+	}
+
+	return c.JSON(models.NewSegments(resp.StatusCode, []models.TeamProfile{team}))
+}
+
+// FetchTeamRegion returns the key of the region a team is ranked in (see
+// regions.Lookup), or "" if vlr.gg does not rank the team.
+func FetchTeamRegion(ctx context.Context, teamID int) (string, error) {
+	rawURL := fmt.Sprintf("%s/team/%d/", utils.BaseURL(), teamID)
+	resp, err := fetch.Get(ctx, rawURL)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		return "", &fetch.StatusError{URL: rawURL, StatusCode: resp.StatusCode}
+	}
+	team, err := ParseTeam(bytes.NewReader(resp.Body))
+	if err != nil {
+		return "", err
+	}
+	region, _ := regions.Lookup(team.Region)
+	return region.Key, nil
+}
+
+// ParseTeam extracts the header, ranking, winnings and roster from a vlr.gg
+// team page. RecentResults and UpcomingMatches are left empty; see
+// ParseMatchHistory.
+func ParseTeam(r io.Reader) (models.TeamProfile, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return models.TeamProfile{}, err
+	}
+
+	var t models.TeamProfile
+	canonical := doc.Find("link[rel='canonical']").AttrOr("href", "")
+	t.TeamID, t.Slug = utils.EntityID(canonical, "team")
+	t.URL = utils.AbsoluteURL(canonical)
+
+	header := doc.Find(".team-header")
+	t.Name = cleanText(header.Find("h1.wf-title").Text())
+	t.Tag = cleanText(header.Find(".team-header-tag").Text())
+	t.Logo = utils.AbsoluteURL(header.Find(".team-header-logo img").AttrOr("src", ""))
+	countryEl := header.Find(".team-header-country")
+	t.Country = country(countryEl.Find("i.flag").AttrOr("class", ""), countryEl.Text())
+	t.Socials = parseSocials(header.Find(".team-header-links a[href^='http']"))
+
+	// Ranking, e.g. "#3 Europe" and "1850 rating"
+	rating := doc.Find(".team-rating-info")
+	rank := strings.TrimPrefix(cleanText(rating.Find(".rank-num").First().Text()), "#")
+	ratingNum := cleanText(rating.Find(".rating-num").First().Text())
+	t.Rank = utils.ParseInt(rank)
+	t.Rating = utils.ParseInt(ratingNum)
+	t.Region = cleanText(rating.Find(".mod-rank .rating-txt").First().Text())
+
+	var winnings string
+	doc.Find(".wf-card").Each(func(_ int, card *goquery.Selection) {
+		if strings.EqualFold(cleanText(card.Find("h2, .wf-label").First().Text()), "Total Winnings") {
+			winnings = cleanText(card.Find("span").First().Text())
+		}
+	})
+	t.TotalWinnings = parseMoney(winnings)
+
+	t.Roster = parseRoster(doc.Find(".team-roster-item"))
+	t.Raw = rawStrings(
+		"rank", rank,
+		"rating", ratingNum,
+		"total_winnings", winnings,
+	)
+	return t, nil
+}
+
+var ratingChangeRe = regexp.MustCompile(`[+−–-]\s*\d+`)
+
+// ParseTeamRatingHistory extracts the team's rating after each completed
+// match from a vlr.gg team match history page, where every result row shows
+// the new rating and the change, e.g. "1965 +12". Rows without one, such as
+// upcoming matches, are skipped.
+func ParseTeamRatingHistory(r io.Reader) ([]models.RatingPoint, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []models.RatingPoint
+	doc.Find("a.m-item").Each(func(_ int, a *goquery.Selection) {
+		elo := cleanText(a.Find(".m-item-elo").Text())
+		if elo == "" {
+			return
+		}
+		change := ratingChangeRe.FindString(elo)
+		rating := strings.Trim(strings.TrimSpace(strings.Replace(elo, change, "", 1)), "[]()")
+		delta := strings.NewReplacer(" ", "", "+", "", "−", "-", "–", "-").Replace(change)
+
+		date := cleanText(a.Find(".m-item-date div").First().Text())
+		p := models.RatingPoint{
+			Date:   parseDate(date),
+			Rating: utils.ParseInt(rating),
+			Change: utils.ParseInt(delta),
+			Raw: rawStrings(
+				"rating", rating,
+				"change", change,
+				"date", date,
+			),
+		}
+		p.MatchID, _ = utils.EntityID(a.AttrOr("href", ""), "")
+		history = append(history, p)
+	})
+	return history, nil
+}
+
+// parseRoster reads the roster cards, which are grouped into containers
+// headed by a "players" or "staff" label. Players without a role line are
+// active.
+func parseRoster(items *goquery.Selection) []models.RosterMember {
+	var roster []models.RosterMember
+	items.Each(func(_ int, item *goquery.Selection) {
+		a := item.Find("a[href*='/player/']").First()
+		alias := item.Find(".team-roster-item-name-alias").First()
+		m := models.RosterMember{
+			Alias:    cleanText(alias.Text()),
+			RealName: cleanText(item.Find(".team-roster-item-name-real").Text()),
+			Country:  country(alias.Find("i.flag").AttrOr("class", ""), ""),
+			Avatar:   utils.AbsoluteURL(item.Find(".team-roster-item-img img").AttrOr("src", "")),
+			Role:     strings.ToLower(cleanText(item.Find(".team-roster-item-name-role").Text())),
+			Captain:  item.Find("i.fa-star").Length() > 0,
+		}
+		m.PlayerID, m.Slug = utils.EntityID(a.AttrOr("href", ""), "player")
+		label := item.Parent().PrevAllFiltered(".wf-module-label").First()
+		m.Staff = strings.EqualFold(cleanText(label.Text()), "staff")
+		if m.Role == "" {
+			m.Role = "player"
+		}
+		roster = append(roster, m)
+	})
+	return roster
+}
@@ -0,0 +1,167 @@
+package scrapers
+
+import (
+	"errors"
+
+	"vlrggapi/internal/webhook"
+	"vlrggapi/pkg/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//
+// VlrWebhookCreate godoc
+// @Summary      Register a webhook
+// @Description  Registers a URL to receive signed JSON POSTs when matches go live (match_started), maps end (map_ended) or results post (match_ended). Optional team_ids, event_ids and regions restrict which matches are sent. The secret, generated if not given, is only returned here.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      models.WebhookRequest  true  "Webhook registration"
+// @Success      201      {object}  models.SegmentsResponse[models.Webhook]
+// @Failure      400      {object}  models.ErrorResponse
+// @Failure      401      {object}  models.ErrorResponse
+// @Failure      500      {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/webhooks [post]
+//
+func VlrWebhookCreate(c *fiber.Ctx) error {
+	hooks := webhook.Default()
+	if hooks == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Webhooks are not available"})
+	}
+	var req models.WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	w, err := hooks.Register(req)
+	var reqErr *webhook.RequestError
+	if errors.As(err, &reqErr) {
+		return c.Status(400).JSON(fiber.Map{"error": reqErr.Reason})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save webhook"})
+	}
+	return c.Status(201).JSON(models.NewSegments(201, []models.Webhook{w}))
+}
+
+//
+// VlrWebhooks godoc
+// @Summary      List webhooks
+// @Description  Returns every registered webhook, oldest first. Secrets are not included.
+// @Tags         webhooks
+// @Produce      json
+// @Success      200  {object}  models.SegmentsResponse[models.Webhook]
+// @Failure      401  {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/webhooks [get]
+//
+func VlrWebhooks(c *fiber.Ctx) error {
+	hooks := webhook.Default()
+	if hooks == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Webhooks are not available"})
+	}
+	return c.JSON(models.NewSegments(200, hooks.List()))
+}
+
+//
+// VlrWebhook godoc
+// @Summary      Get a webhook
+// @Description  Returns a single registered webhook without its secret.
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  models.SegmentsResponse[models.Webhook]
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/webhooks/{id} [get]
+//
+func VlrWebhook(c *fiber.Ctx) error {
+	hooks := webhook.Default()
+	if hooks == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Webhooks are not available"})
+	}
+	w, err := hooks.Get(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Webhook not found"})
+	}
+	return c.JSON(models.NewSegments(200, []models.Webhook{w}))
+}
+
+//
+// VlrWebhookDelete godoc
+// @Summary      Delete a webhook
+// @Description  Removes a webhook. Pending retries for it are dropped.
+// @Tags         webhooks
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      204
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/webhooks/{id} [delete]
+//
+func VlrWebhookDelete(c *fiber.Ctx) error {
+	hooks := webhook.Default()
+	if hooks == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Webhooks are not available"})
+	}
+	err := hooks.Delete(c.Params("id"))
+	if errors.Is(err, webhook.ErrNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Webhook not found"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save webhook"})
+	}
+	return c.SendStatus(204)
+}
+
+//
+// VlrWebhookTest godoc
+// @Summary      Send a test ping to a webhook
+// @Description  POSTs a signed "ping" notification to the webhook once, without retries, and returns the logged delivery. A receiver error is reported in the delivery, not as an error status.
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  models.SegmentsResponse[models.WebhookDelivery]
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/webhooks/{id}/test [post]
+//
+func VlrWebhookTest(c *fiber.Ctx) error {
+	hooks := webhook.Default()
+	if hooks == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Webhooks are not available"})
+	}
+	d, err := hooks.Test(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Webhook not found"})
+	}
+	return c.JSON(models.NewSegments(200, []models.WebhookDelivery{d}))
+}
+
+//
+// VlrWebhookDeliveries godoc
+// @Summary      Get a webhook's delivery log
+// @Description  Returns the most recent delivery attempts for a webhook, newest first, with status code, error, duration and the next retry time if one is scheduled.
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  models.SegmentsResponse[models.WebhookDelivery]
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Security     AdminToken
+// @Router       /vlr/webhooks/{id}/deliveries [get]
+//
+func VlrWebhookDeliveries(c *fiber.Ctx) error {
+	hooks := webhook.Default()
+	if hooks == nil {
+		return c.Status(503).JSON(fiber.Map{"error": "Webhooks are not available"})
+	}
+	log, err := hooks.Deliveries(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Webhook not found"})
+	}
+	return c.JSON(models.NewSegments(200, log))
+}
@@ -0,0 +1,70 @@
+// Package store keeps the most recent scrape of each endpoint variant so
+// handlers can answer from memory instead of waiting on vlr.gg. It is filled
+// by the background refresher and by handlers on a miss. Only the pre-warmed
+// variants are stored, so it holds a fixed number of entries.
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// Entry is one stored scrape.
+type Entry struct {
+	// Value is the response as built by the scraper.
+	Value     any
+	FetchedAt time.Time
+}
+
+// Age is how long ago the entry was scraped.
+func (e Entry) Age(now time.Time) time.Duration {
+	return now.Sub(e.FetchedAt)
+}
+
+// Store is a concurrency-safe map of scrapes keyed by scraper and canonical
+// query.
+type Store struct {
+	mu      sync.RWMutex
+	entries map[string]Entry
+}
+
+// New returns an empty Store.
+func New() *Store {
+	return &Store{entries: make(map[string]Entry)}
+}
+
+var defaultStore = New()
+
+// Default returns the process-wide store used by the scrapers.
+func Default() *Store {
+	return defaultStore
+}
+
+// Get returns the entry stored under key.
+func (s *Store) Get(key string) (Entry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.entries[key]
+	return e, ok
+}
+
+// Set stores an entry under key, replacing any previous one.
+func (s *Store) Set(key string, e Entry) {
+	s.mu.Lock()
+	s.entries[key] = e
+	s.mu.Unlock()
+}
+
+// Delete removes the entry stored under key.
+func (s *Store) Delete(key string) {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+}
+
+// Len returns the number of entries.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
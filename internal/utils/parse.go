@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseInt parses a scraped integer such as "1,204". It returns nil for
+// empty or non-numeric values.
+func ParseInt(s string) *int {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// ParseFloat parses a scraped decimal such as "1.12" or a percentage such as
+// "24%" (returned as 24). It returns nil for empty or non-numeric values.
+func ParseFloat(s string) *float64 {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "%")
+	s = strings.ReplaceAll(s, ",", "")
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return &f
+}
+
+// currencySymbols maps the prefixes vlr.gg uses for prize and earnings
+// amounts to ISO 4217 codes. Longer prefixes come first.
+var currencySymbols = []struct {
+	symbol, code string
+}{
+	{"US$", "USD"},
+	{"R$", "BRL"},
+	{"$", "USD"},
+	{"€", "EUR"},
+	{"£", "GBP"},
+	{"¥", "JPY"},
+	{"₩", "KRW"},
+	{"₱", "PHP"},
+	{"₹", "INR"},
+}
+
+// ParseMoney parses an amount such as "$123,456" into integer cents and an
+// ISO 4217 currency code. Amounts without a recognised symbol are assumed to
+// be USD, which is what vlr.gg displays by default.
+func ParseMoney(s string) (cents int64, currency string, ok bool) {
+	s = strings.TrimSpace(s)
+	currency = "USD"
+	for _, c := range currencySymbols {
+		if strings.HasPrefix(s, c.symbol) {
+			s, currency = strings.TrimPrefix(s, c.symbol), c.code
+			break
+		}
+	}
+	// Some pages put the code after the amount, e.g. "$250,000 USD".
+	if n := len(s); n > 3 && isCurrencyCode(s[n-3:]) {
+		s, currency = s[:n-3], s[n-3:]
+	}
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return int64(math.Round(amount * 100)), currency, true
+}
+
+func isCurrencyCode(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+var recordRe = regexp.MustCompile(`(\d+)\s*[-–—]\s*(\d+)`)
+
+// ParseRecord parses a win/loss record such as "12–3".
+func ParseRecord(s string) (wins, losses int, ok bool) {
+	m := recordRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, false
+	}
+	wins, _ = strconv.Atoi(m[1])
+	losses, _ = strconv.Atoi(m[2])
+	return wins, losses, true
+}
+
+var durationPartRe = regexp.MustCompile(`(\d+)\s*(y|mo|w|d|h|m|s)\b`)
+
+// ParseDuration parses the compact durations vlr.gg shows in countdowns and
+// "ago" labels, e.g. "18m", "1d 2h" or "3w". Months ("mo") and years ("y")
+// count as 30 and 365 days; ParseRelativeTime steps them on the calendar
+// instead.
+func ParseDuration(s string) (time.Duration, bool) {
+	years, months, d, ok := parseDurationParts(s)
+	if !ok {
+		return 0, false
+	}
+	return d + time.Duration(365*years+30*months)*24*time.Hour, true
+}
+
+// parseDurationParts splits a compact duration into its calendar parts and
+// the fixed-length remainder.
+func parseDurationParts(s string) (years, months int, d time.Duration, ok bool) {
+	parts := durationPartRe.FindAllStringSubmatch(strings.ToLower(s), -1)
+	if len(parts) == 0 {
+		return 0, 0, 0, false
+	}
+	for _, p := range parts {
+		n, _ := strconv.Atoi(p[1])
+		switch p[2] {
+		case "y":
+			years += n
+		case "mo":
+			months += n
+		case "w":
+			d += time.Duration(n) * 7 * 24 * time.Hour
+		case "d":
+			d += time.Duration(n) * 24 * time.Hour
+		case "h":
+			d += time.Duration(n) * time.Hour
+		case "m":
+			d += time.Duration(n) * time.Minute
+		case "s":
+			d += time.Duration(n) * time.Second
+		}
+	}
+	return years, months, d, true
+}
+
+// ParseRelativeTime resolves a relative label against now. Labels ending in
+// "ago" are in the past; anything else (e.g. a countdown "18m") is in the
+// future. Months and years, as in "1mo ago" or "2y ago", are calendar
+// months and years. The result is truncated to the minute and in UTC.
+func ParseRelativeTime(s string, now time.Time) (time.Time, bool) {
+	years, months, d, ok := parseDurationParts(s)
+	if !ok {
+		return time.Time{}, false
+	}
+	if strings.HasSuffix(strings.ToLower(strings.TrimSpace(s)), "ago") {
+		years, months, d = -years, -months, -d
+	}
+	return now.UTC().AddDate(years, months, 0).Add(d).Truncate(time.Minute), true
+}
+
+var dateLayouts = []string{
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"Monday, January 2, 2006",
+	"Mon, January 2, 2006",
+	"2006/01/02",
+	"2006-01-02",
+	"January 2006",
+	"Jan 2006",
+}
+
+// ParseDate parses the absolute dates vlr.gg displays (e.g. "October 17,
+// 2026", or "March 2021" in team histories) as midnight UTC.
+func ParseDate(s string) (time.Time, bool) {
+	s = strings.Join(strings.Fields(s), " ")
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
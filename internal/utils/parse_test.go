@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in       string
+		cents    int64
+		currency string
+		ok       bool
+	}{
+		{"$123,456", 12345600, "USD", true},
+		{" $1,000,000 ", 100000000, "USD", true},
+		{"$2,500.50", 250050, "USD", true},
+		{"US$ 250,000", 25000000, "USD", true},
+		{"R$50,000", 5000000, "BRL", true},
+		{"€10,000", 1000000, "EUR", true},
+		{"£500", 50000, "GBP", true},
+		{"¥1,000,000", 100000000, "JPY", true},
+		{"₩5,000,000", 500000000, "KRW", true},
+		{"$250,000 USD", 25000000, "USD", true},
+		{"250,000 EUR", 25000000, "EUR", true},
+		{"1,500", 150000, "USD", true},
+		{"$0", 0, "USD", true},
+		{"", 0, "", false},
+		{"$", 0, "", false},
+		{"TBD", 0, "", false},
+		{"$1.2k", 0, "", false},
+	}
+	for _, tt := range tests {
+		cents, currency, ok := ParseMoney(tt.in)
+		if cents != tt.cents || currency != tt.currency || ok != tt.ok {
+			t.Errorf("ParseMoney(%q) = %d, %q, %v; want %d, %q, %v", tt.in, cents, currency, ok, tt.cents, tt.currency, tt.ok)
+		}
+	}
+}
+
+func TestParseRecord(t *testing.T) {
+	tests := []struct {
+		in           string
+		wins, losses int
+		ok           bool
+	}{
+		{"12–3", 12, 3, true},
+		{"12-3", 12, 3, true},
+		{"0—0", 0, 0, true},
+		{" 7 – 10 ", 7, 10, true},
+		{"W/L: 25-14", 25, 14, true},
+		{"12", 0, 0, false},
+		{"", 0, 0, false},
+		{"–", 0, 0, false},
+	}
+	for _, tt := range tests {
+		wins, losses, ok := ParseRecord(tt.in)
+		if wins != tt.wins || losses != tt.losses || ok != tt.ok {
+			t.Errorf("ParseRecord(%q) = %d, %d, %v; want %d, %d, %v", tt.in, wins, losses, ok, tt.wins, tt.losses, tt.ok)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"18m", 18 * time.Minute, true},
+		{"45s", 45 * time.Second, true},
+		{"1d 2h", 26 * time.Hour, true},
+		{"2h 30m", 150 * time.Minute, true},
+		{"3w", 21 * day, true},
+		{"1mo", 30 * day, true},
+		{"2y", 730 * day, true},
+		{"1y 2mo", 425 * day, true},
+		{"2D 3H", 51 * time.Hour, true},
+		{"5m ago", 5 * time.Minute, true},
+		{"1 h", time.Hour, true},
+		{"LIVE", 0, false},
+		{"TBD", 0, false},
+		{"", 0, false},
+		{"5min", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDuration(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	now := time.Date(2026, 3, 31, 18, 0, 30, 0, time.FixedZone("CEST", 2*60*60))
+	utc := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"18m", utc(2026, 3, 31, 16, 18), true},
+		{"1d 2h", utc(2026, 4, 1, 18, 0), true},
+		{"2h ago", utc(2026, 3, 31, 14, 0), true},
+		{"3w ago", utc(2026, 3, 10, 16, 0), true},
+		{"45s", utc(2026, 3, 31, 16, 1), true},
+		{" 5m AGO ", utc(2026, 3, 31, 15, 55), true},
+		// Months and years step the calendar: March 31 less a month
+		// normalises to March 3, as time.AddDate does
+		{"1mo ago", utc(2026, 3, 3, 16, 0), true},
+		{"2mo ago", utc(2026, 1, 31, 16, 0), true},
+		{"1y ago", utc(2025, 3, 31, 16, 0), true},
+		{"1y 2mo ago", utc(2025, 1, 31, 16, 0), true},
+		{"1mo 2d ago", utc(2026, 3, 1, 16, 0), true},
+		{"6mo", utc(2026, 10, 1, 16, 0), true},
+		{"LIVE", time.Time{}, false},
+		{"", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseRelativeTime(tt.in, now)
+		if !got.Equal(tt.want) || ok != tt.ok {
+			t.Errorf("ParseRelativeTime(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+		if ok && got.Location() != time.UTC {
+			t.Errorf("ParseRelativeTime(%q) is in %v, want UTC", tt.in, got.Location())
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	oct17 := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"October 17, 2026", oct17, true},
+		{"Oct 17, 2026", oct17, true},
+		{"Saturday, October 17, 2026", oct17, true},
+		{"Sat, October 17, 2026", oct17, true},
+		{"2026/10/17", oct17, true},
+		{"2026-10-17", oct17, true},
+		{"  October\n\t17,   2026 ", oct17, true},
+		{"March 2021", time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{"Mar 2021", time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{"17 October 2026", time.Time{}, false},
+		{"October 32, 2026", time.Time{}, false},
+		{"Present", time.Time{}, false},
+		{"", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDate(tt.in)
+		if !got.Equal(tt.want) || ok != tt.ok {
+			t.Errorf("ParseDate(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -1,22 +1,101 @@
 package utils
 
+import (
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultBaseURL is the upstream origin used when VLR_BASE_URL is unset.
+const DefaultBaseURL = "https://www.vlr.gg"
+
+var baseURL = loadBaseURL()
+
+func loadBaseURL() string {
+	base := strings.TrimSpace(os.Getenv("VLR_BASE_URL"))
+	if base == "" {
+		return DefaultBaseURL
+	}
+	return strings.TrimRight(base, "/")
+}
+
+// BaseURL returns the upstream origin (no trailing slash) every scraper
+// fetches from and builds links against. It can be pointed at a local mirror,
+// caching proxy or archived snapshot with VLR_BASE_URL.
+func BaseURL() string {
+	return baseURL
+}
+
+// AbsoluteURL resolves an href scraped from a vlr.gg page. Root-relative and
+// bare paths are joined to BaseURL, protocol-relative CDN links take the base
+// URL's scheme, and absolute URLs are returned unchanged.
+func AbsoluteURL(href string) string {
+	href = strings.TrimSpace(href)
+	switch {
+	case href == "":
+		return ""
+	case strings.HasPrefix(href, "//"):
+		scheme := "https"
+		if u, err := url.Parse(baseURL); err == nil && u.Scheme != "" {
+			scheme = u.Scheme
+		}
+		return scheme + ":" + href
+	case strings.HasPrefix(href, "http://"), strings.HasPrefix(href, "https://"):
+		return href
+	case strings.HasPrefix(href, "/"):
+		return baseURL + href
+	default:
+		return baseURL + "/" + href
+	}
+}
+
 var Headers = map[string]string{
 	"User-Agent": "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:52.0) Gecko/20100101 Firefox/52.0",
 }
 
-var Region = map[string]string{
-	"na":   "north-america",
-	"eu":   "europe",
-	"ap":   "asia-pacific",
-	"la":   "latin-america",
-	"la-s": "la-s",
-	"la-n": "la-n",
-	"oce":  "oceania",
-	"kr":   "korea",
-	"mn":   "mena",
-	"gc":   "gc",
-	"br":   "Brazil",
-	"cn":   "china",
-	"jp":   "japan",
-	"col":  "collegiate",
+// Entity is a vlr.gg object identified by the numeric ID and slug in its URL.
+type Entity struct {
+	// Kind is the first path segment for typed pages ("team", "player",
+	// "event", ...) and empty for matches and news articles, which live at
+	// the root (/12345/team-a-vs-team-b).
+	Kind string
+	ID   int
+	Slug string
+}
+
+// ParseEntityURL extracts the kind, numeric ID and slug from a vlr.gg link.
+// It accepts absolute URLs and paths, and skips sub-pages so that
+// "/event/matches/2097/champions" yields event 2097.
+func ParseEntityURL(href string) (Entity, bool) {
+	href = strings.TrimSpace(href)
+	if u, err := url.Parse(href); err == nil {
+		href = u.Path
+	}
+	segs := strings.FieldsFunc(href, func(r rune) bool { return r == '/' })
+	for i, seg := range segs {
+		id, err := strconv.Atoi(seg)
+		if err != nil || id <= 0 {
+			continue
+		}
+		e := Entity{ID: id}
+		if i > 0 {
+			e.Kind = segs[0]
+		}
+		if i+1 < len(segs) {
+			e.Slug = segs[i+1]
+		}
+		return e, true
+	}
+	return Entity{}, false
+}
+
+// EntityID returns the ID and slug of a vlr.gg link of the given kind
+// ("" for matches and news), or zero values if href is not such a link.
+func EntityID(href, kind string) (int, string) {
+	e, ok := ParseEntityURL(href)
+	if !ok || e.Kind != kind {
+		return 0, ""
+	}
+	return e.ID, e.Slug
 }
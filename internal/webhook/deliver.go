@@ -0,0 +1,192 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"vlrggapi/pkg/models"
+)
+
+// Headers sent with every delivery.
+const (
+	HeaderEvent     = "X-Vlrggapi-Event"
+	HeaderDelivery  = "X-Vlrggapi-Delivery"
+	HeaderTimestamp = "X-Vlrggapi-Timestamp"
+	HeaderSignature = "X-Vlrggapi-Signature"
+)
+
+// Sign returns the signature header value for a delivery: "sha256=" and the
+// hex HMAC-SHA256, keyed by the webhook secret, of the timestamp header
+// value, a dot and the raw body. Receivers should recompute it and reject
+// old timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is valid for the timestamp and body.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// maxRetryDelay caps the backoff between attempts.
+const maxRetryDelay = time.Hour
+
+// job is one notification for one webhook.
+type job struct {
+	hookID  string
+	payload models.WebhookPayload
+	attempt int
+}
+
+// enqueue hands j to the workers without blocking. A full queue is logged
+// as a failed delivery.
+func (m *Manager) enqueue(j job) {
+	select {
+	case m.queue <- j:
+	default:
+		m.record(j.hookID, models.WebhookDelivery{
+			ID:      j.payload.ID,
+			Event:   j.payload.Event,
+			Attempt: j.attempt,
+			Error:   "delivery queue full",
+			At:      time.Now().UTC(),
+		})
+	}
+}
+
+// work delivers queued notifications until ctx is cancelled, scheduling a
+// retry for each failed attempt that has attempts left.
+func (m *Manager) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-m.queue:
+			d, retry := m.deliver(ctx, j)
+			if retry && j.attempt < m.cfg.MaxAttempts {
+				delay := retryDelay(m.cfg.RetryDelay, j.attempt)
+				next := d.At.Add(delay)
+				d.NextRetry = &next
+				j.attempt++
+				time.AfterFunc(delay, func() { m.enqueue(j) })
+			}
+			m.record(j.hookID, d)
+		}
+	}
+}
+
+// retryDelay returns base * 2^(attempt-1), capped at maxRetryDelay.
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	if attempt > 30 {
+		return maxRetryDelay
+	}
+	return min(base<<(attempt-1), maxRetryDelay)
+}
+
+// Test sends a ping to a webhook right away, without retries, and returns
+// the logged delivery.
+func (m *Manager) Test(ctx context.Context, id string) (models.WebhookDelivery, error) {
+	if _, err := m.Get(id); err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	j := job{hookID: id, payload: newPayload(id, EventPing, nil), attempt: 1}
+	d, _ := m.deliver(ctx, j)
+	m.record(id, d)
+	return d, nil
+}
+
+// deliver makes one attempt at POSTing j. It reports whether a failure is
+// worth retrying: network errors, timeouts, 408, 429 and 5xx are.
+func (m *Manager) deliver(ctx context.Context, j job) (models.WebhookDelivery, bool) {
+	d := models.WebhookDelivery{
+		ID:      j.payload.ID,
+		Event:   j.payload.Event,
+		Attempt: j.attempt,
+		At:      time.Now().UTC(),
+	}
+
+	m.mu.Lock()
+	h, ok := m.hooks[j.hookID]
+	var target, secret string
+	if ok {
+		target, secret = h.URL, h.Secret
+	}
+	m.mu.Unlock()
+	if !ok {
+		d.Error = ErrNotFound.Error()
+		return d, false
+	}
+
+	body, err := json.Marshal(j.payload)
+	if err != nil {
+		d.Error = err.Error()
+		return d, false
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		d.Error = err.Error()
+		return d, false
+	}
+	ts := d.At.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "vlrggapi-webhook/1.0")
+	req.Header.Set(HeaderEvent, j.payload.Event)
+	req.Header.Set(HeaderDelivery, j.payload.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, ts, body))
+
+	resp, err := m.client.Do(req)
+	d.DurationMS = time.Since(d.At).Milliseconds()
+	if err != nil {
+		d.Error = err.Error()
+		return d, true
+	}
+	resp.Body.Close()
+
+	d.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		d.Success = true
+		return d, false
+	}
+	d.Error = fmt.Sprintf("receiver returned status %d", resp.StatusCode)
+	retry := resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500
+	return d, retry
+}
+
+// record prepends d to a webhook's delivery log, trimming it to LogSize.
+func (m *Manager) record(hookID string, d models.WebhookDelivery) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.hooks[hookID]
+	if !ok {
+		return
+	}
+	h.log = append([]models.WebhookDelivery{d}, h.log...)
+	if len(h.log) > m.cfg.LogSize {
+		h.log = h.log[:m.cfg.LogSize]
+	}
+}
+
+func newPayload(hookID, event string, data *models.LiveEvent) models.WebhookPayload {
+	return models.WebhookPayload{
+		ID:        randomHex(8),
+		WebhookID: hookID,
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+}
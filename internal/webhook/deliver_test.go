@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"vlrggapi/pkg/models"
+)
+
+// attempt is one request seen by the test receiver.
+type attempt struct {
+	at       time.Time
+	delivery string
+	signed   bool
+}
+
+// receiver starts a server that checks each request's signature against
+// secret and answers with the next status in statuses, repeating the last
+// one.
+func receiver(t *testing.T, secret string, statuses ...int) (*httptest.Server, <-chan attempt) {
+	t.Helper()
+	attempts := make(chan attempt, 16)
+	n := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		attempts <- attempt{
+			at:       time.Now(),
+			delivery: r.Header.Get(HeaderDelivery),
+			signed:   err == nil && Verify(secret, ts, body, r.Header.Get(HeaderSignature)),
+		}
+		w.WriteHeader(statuses[min(n, len(statuses)-1)])
+		n++
+	}))
+	t.Cleanup(srv.Close)
+	return srv, attempts
+}
+
+// newTestManager returns a Manager with its delivery workers running and a
+// webhook registered for url.
+func newTestManager(t *testing.T, cfg Config, region RegionFunc, req models.WebhookRequest) (*Manager, models.Webhook) {
+	t.Helper()
+	m, err := New(cfg, region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	for range m.cfg.Workers {
+		go m.work(ctx)
+	}
+	go m.resolveRegions(ctx)
+	w, err := m.Register(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, w
+}
+
+func next(t *testing.T, attempts <-chan attempt) attempt {
+	t.Helper()
+	select {
+	case a := <-attempts:
+		return a
+	case <-time.After(5 * time.Second):
+		t.Fatal("no delivery received")
+		return attempt{}
+	}
+}
+
+func TestDeliverySignedAndRetriedWithBackoff(t *testing.T) {
+	const secret = "s3cret"
+	const base = 50 * time.Millisecond
+	srv, attempts := receiver(t, secret, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNoContent)
+	m, w := newTestManager(t, Config{MaxAttempts: 5, RetryDelay: base, Timeout: time.Second},
+		nil, models.WebhookRequest{URL: srv.URL, Secret: secret})
+
+	m.enqueue(job{hookID: w.ID, payload: newPayload(w.ID, EventPing, nil), attempt: 1})
+
+	var got []attempt
+	for range 3 {
+		got = append(got, next(t, attempts))
+	}
+	for i, a := range got {
+		if !a.signed {
+			t.Errorf("attempt %d: signature does not verify", i+1)
+		}
+		if a.delivery != got[0].delivery {
+			t.Errorf("attempt %d: delivery ID %q, want %q kept across retries", i+1, a.delivery, got[0].delivery)
+		}
+	}
+	// The delay doubles after every failed attempt
+	for i, want := range []time.Duration{base, 2 * base} {
+		if gap := got[i+1].at.Sub(got[i].at); gap < want {
+			t.Errorf("retry %d after %v, want at least %v", i+1, gap, want)
+		}
+	}
+
+	select {
+	case a := <-attempts:
+		t.Fatalf("unexpected attempt after success: %+v", a)
+	case <-time.After(4 * base):
+	}
+
+	deliveries, err := m.Deliveries(w.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 3 {
+		t.Fatalf("got %d logged deliveries, want 3", len(deliveries))
+	}
+	if d := deliveries[0]; !d.Success || d.StatusCode != http.StatusNoContent || d.Attempt != 3 {
+		t.Errorf("last delivery = %+v, want attempt 3 succeeding with 204", d)
+	}
+	for _, d := range deliveries[1:] {
+		if d.Success || d.NextRetry == nil {
+			t.Errorf("failed delivery %+v has no retry scheduled", d)
+		}
+	}
+}
+
+func TestDeliveryGivesUp(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		attempts int
+	}{
+		{"client error is not retried", http.StatusBadRequest, 1},
+		{"server error stops at MaxAttempts", http.StatusInternalServerError, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, attempts := receiver(t, "secret", tt.status)
+			m, w := newTestManager(t, Config{MaxAttempts: 3, RetryDelay: time.Millisecond, Timeout: time.Second},
+				nil, models.WebhookRequest{URL: srv.URL, Secret: "secret"})
+
+			m.enqueue(job{hookID: w.ID, payload: newPayload(w.ID, EventPing, nil), attempt: 1})
+			for range tt.attempts {
+				next(t, attempts)
+			}
+			select {
+			case <-attempts:
+				t.Fatalf("more than %d attempts", tt.attempts)
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+	}
+}
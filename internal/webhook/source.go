@@ -0,0 +1,192 @@
+package webhook
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"vlrggapi/internal/live"
+	"vlrggapi/pkg/models"
+)
+
+// Run starts the delivery workers and the region resolver and turns the
+// poller's events into notifications until ctx is cancelled. It only subscribes to the poller
+// while at least one webhook is registered, so an idle server does not poll
+// vlr.gg on its behalf.
+func (m *Manager) Run(ctx context.Context, poller *live.Poller) {
+	for range m.cfg.Workers {
+		go m.work(ctx)
+	}
+	go m.resolveRegions(ctx)
+
+	for {
+		if !m.hasHooks() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.changed:
+				continue
+			}
+		}
+		m.follow(ctx, poller)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// follow handles events from one poller subscription until the last webhook
+// is removed, the subscription is dropped or ctx is cancelled.
+func (m *Manager) follow(ctx context.Context, poller *live.Poller) {
+	events, cancel := poller.Subscribe()
+	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.changed:
+			if !m.hasHooks() {
+				return
+			}
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			m.dispatch(ev)
+		}
+	}
+}
+
+// regionJob is an event whose region-filtered webhooks wait on a team
+// region that has not been resolved yet.
+type regionJob struct {
+	ev    models.LiveEvent
+	hooks []models.Webhook
+}
+
+// dispatch queues a notification for every webhook that wants ev. It runs on
+// the poller subscription and never blocks: webhooks whose filters need a
+// team region that is not known yet are handed to resolveRegions.
+func (m *Manager) dispatch(ev models.LiveEvent) {
+	if !slices.Contains(Events, ev.Type) || ev.Match == nil {
+		return
+	}
+	m.mu.Lock()
+	hooks := make([]models.Webhook, 0, len(m.hooks))
+	for _, h := range m.hooks {
+		if slices.Contains(h.Events, ev.Type) {
+			hooks = append(hooks, h.Webhook)
+		}
+	}
+	m.mu.Unlock()
+
+	var pending []models.Webhook
+	for _, w := range hooks {
+		matched, known := m.matches(w, ev.Match)
+		switch {
+		case matched:
+			m.enqueue(job{hookID: w.ID, payload: newPayload(w.ID, ev.Type, &ev), attempt: 1})
+		case !known:
+			pending = append(pending, w)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+	select {
+	case m.lookups <- regionJob{ev: ev, hooks: pending}:
+	default:
+		for _, w := range pending {
+			m.record(w.ID, models.WebhookDelivery{
+				Event:   ev.Type,
+				Attempt: 1,
+				Error:   "region lookup queue full",
+				At:      time.Now().UTC(),
+			})
+		}
+	}
+}
+
+// resolveRegions looks up the team regions queued by dispatch until ctx is
+// cancelled, then queues the notifications whose filters match. Lookups
+// fetch team pages, so they run here rather than on the poller subscription.
+func (m *Manager) resolveRegions(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case rj := <-m.lookups:
+			m.resolveRegion(ctx, rj.ev.Match.Team1ID)
+			m.resolveRegion(ctx, rj.ev.Match.Team2ID)
+			for _, w := range rj.hooks {
+				if matched, _ := m.matches(w, rj.ev.Match); matched {
+					m.enqueue(job{hookID: w.ID, payload: newPayload(w.ID, rj.ev.Type, &rj.ev), attempt: 1})
+				}
+			}
+		}
+	}
+}
+
+// matches applies a webhook's filters to a match. Without filters every
+// match passes; otherwise the team, event or either team's region must be
+// listed. Only resolved regions are consulted; known is false when the
+// answer may change once a team's region is resolved.
+func (m *Manager) matches(w models.Webhook, match *models.LiveMatch) (matched, known bool) {
+	if len(w.TeamIDs) == 0 && len(w.EventIDs) == 0 && len(w.Regions) == 0 {
+		return true, true
+	}
+	if slices.Contains(w.EventIDs, match.EventID) ||
+		slices.Contains(w.TeamIDs, match.Team1ID) ||
+		slices.Contains(w.TeamIDs, match.Team2ID) {
+		return true, true
+	}
+	if len(w.Regions) == 0 {
+		return false, true
+	}
+	known = true
+	for _, teamID := range []int{match.Team1ID, match.Team2ID} {
+		region, ok := m.cachedRegion(teamID)
+		if !ok {
+			known = false
+			continue
+		}
+		if region != "" && slices.Contains(w.Regions, region) {
+			return true, true
+		}
+	}
+	return false, known
+}
+
+// cachedRegion returns a team's region if it has been resolved. Teams that
+// cannot be resolved count as resolved to no region.
+func (m *Manager) cachedRegion(teamID int) (string, bool) {
+	if teamID <= 0 || m.region == nil {
+		return "", true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	region, ok := m.teams[teamID]
+	return region, ok
+}
+
+// resolveRegion looks up a team's region and caches it for the life of the
+// process. Failed lookups are not cached, so webhooks filtered on that
+// region miss the event.
+func (m *Manager) resolveRegion(ctx context.Context, teamID int) {
+	if _, ok := m.cachedRegion(teamID); ok {
+		return
+	}
+	region, err := m.region(ctx, teamID)
+	if err != nil {
+		return
+	}
+	m.mu.Lock()
+	m.teams[teamID] = region
+	m.mu.Unlock()
+}
+
+func (m *Manager) hasHooks() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.hooks) > 0
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"vlrggapi/internal/live"
+	"vlrggapi/pkg/models"
+)
+
+func TestDispatchDoesNotWaitForRegionLookup(t *testing.T) {
+	release := make(chan struct{})
+	region := func(ctx context.Context, teamID int) (string, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if teamID == 2 {
+			return "eu", nil
+		}
+		return "na", nil
+	}
+	srv, attempts := receiver(t, "secret", http.StatusOK)
+	m, _ := newTestManager(t, Config{Timeout: time.Second}, region,
+		models.WebhookRequest{URL: srv.URL, Secret: "secret", Regions: []string{"eu"}})
+
+	ev := models.LiveEvent{Type: live.EventMatchStarted, Match: &models.LiveMatch{Team1ID: 1, Team2ID: 2}}
+	done := make(chan struct{})
+	go func() {
+		m.dispatch(ev)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatch blocked on the region lookup")
+	}
+
+	close(release)
+	next(t, attempts)
+
+	// Resolved regions are cached, so a later event is matched in dispatch
+	m.dispatch(ev)
+	next(t, attempts)
+	if matched, known := m.matches(models.Webhook{Regions: []string{"kr"}}, ev.Match); matched || !known {
+		t.Errorf("matches for an unlisted region = %v, %v; want false, true", matched, known)
+	}
+}
@@ -0,0 +1,365 @@
+// Package webhook POSTs match lifecycle notifications to registered URLs.
+// Notifications come from the live poller, are signed with HMAC-SHA256,
+// retried with exponential backoff and recorded in a per-webhook delivery
+// log.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"vlrggapi/internal/live"
+	"vlrggapi/internal/regions"
+	"vlrggapi/pkg/models"
+)
+
+// EventPing is the notification sent by Manager.Test.
+const EventPing = "ping"
+
+// Events are the live event types a webhook can receive.
+var Events = []string{live.EventMatchStarted, live.EventMapEnded, live.EventMatchEnded}
+
+// ErrNotFound is returned for an unknown webhook ID.
+var ErrNotFound = errors.New("webhook not found")
+
+// RequestError is returned by Register for an invalid registration.
+type RequestError struct {
+	Reason string
+}
+
+func (e *RequestError) Error() string {
+	return e.Reason
+}
+
+// Config controls delivery and storage.
+type Config struct {
+	// MaxAttempts is the total number of attempts per notification.
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry; it doubles every retry.
+	RetryDelay time.Duration
+	// Timeout bounds a single POST.
+	Timeout time.Duration
+	// Workers is the number of concurrent deliveries.
+	Workers int
+	// QueueSize caps the number of notifications waiting for a worker.
+	QueueSize int
+	// LogSize is the number of deliveries kept per webhook.
+	LogSize int
+	// StorePath, if set, is a JSON file registrations are saved to and
+	// loaded from, so they survive restarts.
+	StorePath string
+}
+
+// DefaultConfig returns the configuration used when nothing is overridden.
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts: 5,
+		RetryDelay:  10 * time.Second,
+		Timeout:     10 * time.Second,
+		Workers:     4,
+		QueueSize:   1024,
+		LogSize:     50,
+	}
+}
+
+// ConfigFromEnv returns DefaultConfig with any WEBHOOK_* environment
+// overrides applied. Invalid values are ignored.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	if n, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS")); err == nil && n > 0 {
+		cfg.MaxAttempts = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("WEBHOOK_RETRY_DELAY")); err == nil && d > 0 {
+		cfg.RetryDelay = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("WEBHOOK_TIMEOUT")); err == nil && d > 0 {
+		cfg.Timeout = d
+	}
+	cfg.StorePath = os.Getenv("WEBHOOK_STORE")
+	return cfg
+}
+
+// RegionFunc returns the region key a team is ranked in.
+type RegionFunc func(ctx context.Context, teamID int) (string, error)
+
+// Manager holds the registered webhooks and delivers notifications to them.
+// It is safe for concurrent use.
+type Manager struct {
+	cfg     Config
+	client  *http.Client
+	queue   chan job
+	changed chan struct{}
+	region  RegionFunc
+	lookups chan regionJob
+
+	mu    sync.Mutex
+	hooks map[string]*hook
+	teams map[int]string
+}
+
+type hook struct {
+	models.Webhook
+	log []models.WebhookDelivery
+}
+
+// New returns a Manager, loading saved registrations from cfg.StorePath if
+// the file exists. region resolves team regions for region filters and may
+// be nil, in which case region filters match nothing. Call Run to start
+// delivering.
+func New(cfg Config, region RegionFunc) (*Manager, error) {
+	def := DefaultConfig()
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = def.MaxAttempts
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = def.Workers
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = def.QueueSize
+	}
+	if cfg.LogSize <= 0 {
+		cfg.LogSize = def.LogSize
+	}
+	m := &Manager{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		queue:   make(chan job, cfg.QueueSize),
+		changed: make(chan struct{}, 1),
+		region:  region,
+		lookups: make(chan regionJob, cfg.QueueSize),
+		hooks:   make(map[string]*hook),
+		teams:   make(map[int]string),
+	}
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultManager *Manager
+)
+
+// Default returns the process-wide manager, or nil if none was set.
+func Default() *Manager {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultManager
+}
+
+// SetDefault replaces the process-wide manager. It is intended to be called
+// once during startup.
+func SetDefault(m *Manager) {
+	defaultMu.Lock()
+	defaultManager = m
+	defaultMu.Unlock()
+}
+
+// Register validates req and adds a webhook. The returned webhook includes
+// its secret; later reads do not.
+func (m *Manager) Register(req models.WebhookRequest) (models.Webhook, error) {
+	w, err := newWebhook(req)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks[w.ID] = &hook{Webhook: w}
+	if err := m.save(); err != nil {
+		delete(m.hooks, w.ID)
+		return models.Webhook{}, err
+	}
+	m.notify()
+	return w, nil
+}
+
+// List returns every webhook, oldest first, without secrets.
+func (m *Manager) List() []models.Webhook {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := make([]models.Webhook, 0, len(m.hooks))
+	for _, h := range m.hooks {
+		list = append(list, public(h.Webhook))
+	}
+	slices.SortFunc(list, func(a, b models.Webhook) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return list
+}
+
+// Get returns a webhook without its secret.
+func (m *Manager) Get(id string) (models.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.hooks[id]
+	if !ok {
+		return models.Webhook{}, ErrNotFound
+	}
+	return public(h.Webhook), nil
+}
+
+// Delete removes a webhook. Queued notifications for it are dropped.
+func (m *Manager) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.hooks[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(m.hooks, id)
+	if err := m.save(); err != nil {
+		m.hooks[id] = h
+		return err
+	}
+	m.notify()
+	return nil
+}
+
+// Deliveries returns a webhook's delivery log, newest first.
+func (m *Manager) Deliveries(id string) ([]models.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.hooks[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]models.WebhookDelivery{}, h.log...), nil
+}
+
+// notify wakes Run after the set of webhooks changed. m.mu must be held.
+func (m *Manager) notify() {
+	select {
+	case m.changed <- struct{}{}:
+	default:
+	}
+}
+
+func newWebhook(req models.WebhookRequest) (models.Webhook, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return models.Webhook{}, &RequestError{"url must be an absolute http or https URL"}
+	}
+
+	events := slices.Clone(Events)
+	if len(req.Events) > 0 {
+		events = nil
+		for _, e := range req.Events {
+			if !slices.Contains(Events, e) {
+				return models.Webhook{}, &RequestError{fmt.Sprintf("unknown event %q, valid events are %v", e, Events)}
+			}
+			if !slices.Contains(events, e) {
+				events = append(events, e)
+			}
+		}
+	}
+
+	regionKeys := []string{}
+	for _, r := range req.Regions {
+		region, ok := regions.Lookup(r)
+		if !ok {
+			return models.Webhook{}, &RequestError{fmt.Sprintf("unknown region %q, valid regions are %v", r, regions.Keys())}
+		}
+		if !slices.Contains(regionKeys, region.Key) {
+			regionKeys = append(regionKeys, region.Key)
+		}
+	}
+
+	for _, ids := range [][]int{req.TeamIDs, req.EventIDs} {
+		for _, id := range ids {
+			if id <= 0 {
+				return models.Webhook{}, &RequestError{"team_ids and event_ids must be positive"}
+			}
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret = randomHex(32)
+	}
+	return models.Webhook{
+		ID:        randomHex(8),
+		URL:       u.String(),
+		Events:    events,
+		TeamIDs:   append([]int{}, req.TeamIDs...),
+		EventIDs:  append([]int{}, req.EventIDs...),
+		Regions:   regionKeys,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+func public(w models.Webhook) models.Webhook {
+	w.Secret = ""
+	return w
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// load reads saved registrations. A missing file is not an error.
+func (m *Manager) load() error {
+	if m.cfg.StorePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(m.cfg.StorePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var saved []models.Webhook
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("webhook store %s: %w", m.cfg.StorePath, err)
+	}
+	for _, w := range saved {
+		m.hooks[w.ID] = &hook{Webhook: w}
+	}
+	return nil
+}
+
+// save writes every registration, secrets included, to the store file.
+// m.mu must be held.
+func (m *Manager) save() error {
+	if m.cfg.StorePath == "" {
+		return nil
+	}
+	saved := make([]models.Webhook, 0, len(m.hooks))
+	for _, h := range m.hooks {
+		saved = append(saved, h.Webhook)
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(m.cfg.StorePath), ".webhooks-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), m.cfg.StorePath)
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// FetchStats counts how many upstream requests were coalesced: concurrent
+// requests for the same vlr.gg URL share one fetch. NotModified is how many
+// fetches vlr.gg answered with 304 to a conditional request.
+type FetchStats struct {
+	Requests     uint64 `json:"requests"`
+	Fetches      uint64 `json:"fetches"`
+	Deduplicated uint64 `json:"deduplicated"`
+	InFlight     int64  `json:"in_flight"`
+	NotModified  uint64 `json:"not_modified"`
+}
+
+// CacheKey is one cached response as listed by the admin cache endpoint.
+// Size is in bytes; Age, TTL and Stale are in seconds. State is "fresh"
+// within the TTL and "stale" in the stale window after it.
+type CacheKey struct {
+	Key      string    `json:"key"`
+	Route    string    `json:"route"`
+	Size     int64     `json:"size"`
+	Age      int64     `json:"age"`
+	StoredAt time.Time `json:"stored_at"`
+	TTL      int64     `json:"ttl"`
+	Stale    int64     `json:"stale"`
+	State    string    `json:"state"`
+}
+
+// CachePurgeRequest selects the cached responses to purge: exactly one of
+// Key, a full request URL such as "/vlr/match?q=results", or Prefix, such as
+// "/vlr/match" for everything under that route.
+type CachePurgeRequest struct {
+	Key    string `json:"key,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// CachePurgeResult reports what a purge removed. Scrapers lists the
+// background-refreshed endpoints whose stored data was dropped as well, so
+// that their next request scrapes vlr.gg again.
+type CachePurgeResult struct {
+	Purged   int      `json:"purged"`
+	Scrapers []string `json:"scrapers"`
+}
+
+// CacheRouteStats is the cache hit/miss count of one route pattern. Stale
+// responses count as hits in HitRatio.
+type CacheRouteStats struct {
+	Route    string  `json:"route"`
+	Hits     uint64  `json:"hits"`
+	Stale    uint64  `json:"stale"`
+	Misses   uint64  `json:"misses"`
+	HitRatio float64 `json:"hit_ratio"`
+}
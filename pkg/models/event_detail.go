@@ -0,0 +1,98 @@
+package models
+
+// EventDetail is the overview page of a vlr.gg event.
+type EventDetail struct {
+	EventID  int    `json:"event_id"`
+	Slug     string `json:"slug,omitempty"`
+	Name     string `json:"name"`
+	Subtitle string `json:"subtitle"`
+	Logo     string `json:"logo"`
+	URL      string `json:"url"`
+	Dates    string `json:"dates"`
+	Prize    *Money `json:"prize"`
+	Location string `json:"location"`
+	// Stages are in page order, e.g. "Group Stage" then "Playoffs".
+	Stages []EventStage     `json:"stages"`
+	Teams  []EventTeam      `json:"teams"`
+	Prizes []PrizePlacement `json:"prizes"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
+
+// EventStage is a named phase of an event with its group tables and/or
+// bracket.
+type EventStage struct {
+	Name     string           `json:"name"`
+	Groups   []GroupStandings `json:"groups"`
+	Brackets []Bracket        `json:"brackets"`
+}
+
+// GroupStandings is one group table, in standings order.
+type GroupStandings struct {
+	Name string          `json:"name"`
+	Rows []GroupStanding `json:"rows"`
+}
+
+// GroupStanding is a team's row in a group table.
+type GroupStanding struct {
+	Position  int    `json:"position"`
+	TeamID    int    `json:"team_id,omitempty"`
+	Team      string `json:"team"`
+	Wins      *int   `json:"wins"`
+	Losses    *int   `json:"losses"`
+	Ties      *int   `json:"ties"`
+	MapDiff   *int   `json:"map_diff"`
+	RoundDiff *int   `json:"round_diff"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
+
+// Bracket is one side of an elimination bracket. Name is "upper", "lower"
+// or empty for single-elimination brackets.
+type Bracket struct {
+	Name   string         `json:"name"`
+	Rounds []BracketRound `json:"rounds"`
+}
+
+// BracketRound is a column of a bracket, e.g. "Upper Quarterfinals".
+type BracketRound struct {
+	Name    string         `json:"name"`
+	Matches []BracketMatch `json:"matches"`
+}
+
+// BracketMatch is a slot in a bracket round. MatchID is omitted for slots
+// whose match has not been scheduled.
+type BracketMatch struct {
+	MatchID   int         `json:"match_id,omitempty"`
+	MatchPage string      `json:"match_page,omitempty"`
+	Team1     BracketSlot `json:"team1"`
+	Team2     BracketSlot `json:"team2"`
+}
+
+// BracketSlot is one team of a bracket match; Name is "TBD" until decided.
+type BracketSlot struct {
+	Name   string `json:"name"`
+	Score  *int   `json:"score"`
+	Winner bool   `json:"winner"`
+}
+
+// EventTeam is a participating team. Seed is the qualification note vlr.gg
+// shows under the team, e.g. "EMEA #1".
+type EventTeam struct {
+	TeamID int    `json:"team_id,omitempty"`
+	Slug   string `json:"slug,omitempty"`
+	Name   string `json:"name"`
+	Logo   string `json:"logo"`
+	Seed   string `json:"seed"`
+}
+
+// PrizePlacement is a row of the prize distribution. Teams that tie for a
+// place (e.g. "5th–6th") get one row each.
+type PrizePlacement struct {
+	Place  string `json:"place"`
+	Prize  *Money `json:"prize"`
+	TeamID int    `json:"team_id,omitempty"`
+	Team   string `json:"team"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// Event is an event card from the vlr.gg events listing.
+type Event struct {
+	EventID int    `json:"event_id,omitempty"`
+	Slug    string `json:"slug,omitempty"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	Prize   *Money `json:"prize"`
+	Dates   string `json:"dates"`
+	// Country is the event location; vlr.gg uses region flags for online
+	// and multi-country events.
+	Country *Country `json:"country"`
+	Thumb   string   `json:"thumb"`
+	URLPath string   `json:"url_path"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// LiveEvent is one message on the /vlr/live/stream feed.
+type LiveEvent struct {
+	// Type is "snapshot", "match_started", "round_won", "map_ended" or
+	// "match_ended".
+	Type    string `json:"type"`
+	MatchID int    `json:"match_id,omitempty"`
+	// Team is 1 or 2 for the team that won the round, map or match, 0 when
+	// not applicable or unknown.
+	Team int `json:"team,omitempty"`
+	// Match is the match state after the change; for match_ended it is the
+	// last state seen while live.
+	Match *LiveMatch `json:"match,omitempty"`
+	// Matches is the full list of live matches, only set on snapshot events
+	// (and omitted there when nothing is live).
+	Matches []LiveMatch `json:"matches,omitempty"`
+	At      time.Time   `json:"at"`
+}
+
+// LiveSocketRequest is a client message on /vlr/live/ws. Action is
+// "subscribe" or "unsubscribe"; any combination of IDs may be given.
+type LiveSocketRequest struct {
+	Action  string `json:"action"`
+	MatchID int    `json:"match_id,omitempty"`
+	EventID int    `json:"event_id,omitempty"`
+	TeamID  int    `json:"team_id,omitempty"`
+}
+
+// LiveSocketReply is a control message sent on /vlr/live/ws. Type is
+// "subscribed", "unsubscribed", "heartbeat" or "error". Score changes are
+// sent as LiveEvent messages instead.
+type LiveSocketReply struct {
+	Type string `json:"type"`
+	// Subscriptions is the connection's full subscription list after a
+	// subscribe or unsubscribe.
+	Subscriptions *LiveSubscriptions `json:"subscriptions,omitempty"`
+	// Matches holds the currently live matches that a subscribe covers.
+	Matches []LiveMatch `json:"matches,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	At      time.Time   `json:"at"`
+}
+
+// LiveSubscriptions lists the IDs a WebSocket connection is subscribed to.
+type LiveSubscriptions struct {
+	MatchIDs []int `json:"match_ids"`
+	EventIDs []int `json:"event_ids"`
+	TeamIDs  []int `json:"team_ids"`
+}
@@ -0,0 +1,207 @@
+package models
+
+import "time"
+
+// MatchDetail is the full breakdown of a single vlr.gg match page.
+type MatchDetail struct {
+	MatchID   int            `json:"match_id"`
+	MatchSlug string         `json:"match_slug,omitempty"`
+	URL       string         `json:"url"`
+	Event     MatchEventInfo `json:"event"`
+	Date      *time.Time     `json:"date"`
+	Patch     string         `json:"patch,omitempty"`
+	// Status is the header note, e.g. "final", "live" or a countdown.
+	Status        string       `json:"status"`
+	BestOf        *int         `json:"best_of"`
+	Teams         []MatchTeam  `json:"teams"`
+	Vetoes        []MapVeto    `json:"vetoes"`
+	Maps          []MatchMap   `json:"maps"`
+	SeriesPlayers []PlayerGame `json:"series_players"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
+
+// MatchEventInfo identifies the event and series stage a match belongs to.
+type MatchEventInfo struct {
+	EventID int    `json:"event_id,omitempty"`
+	Name    string `json:"name"`
+	Series  string `json:"series"`
+	URL     string `json:"url"`
+}
+
+// MatchTeam is one side of a match header.
+type MatchTeam struct {
+	TeamID int    `json:"team_id,omitempty"`
+	Slug   string `json:"slug,omitempty"`
+	Name   string `json:"name"`
+	Logo   string `json:"logo"`
+	Score  *int   `json:"score"`
+	Winner bool   `json:"winner"`
+}
+
+// MapVeto is one step of the pick/ban sequence. Action is "ban", "pick" or
+// "remains" (the decider, which has no team).
+type MapVeto struct {
+	Order  int    `json:"order"`
+	Team   string `json:"team,omitempty"`
+	Action string `json:"action"`
+	Map    string `json:"map"`
+}
+
+// MatchMap is a single map played in a series.
+type MatchMap struct {
+	GameID   int    `json:"game_id"`
+	Map      string `json:"map"`
+	PickedBy string `json:"picked_by,omitempty"`
+	Duration string `json:"duration,omitempty"`
+	// Winner is 1 or 2 for the winning team, 0 if the map is unfinished.
+	Winner  int          `json:"winner"`
+	Team1   MapScore     `json:"team1"`
+	Team2   MapScore     `json:"team2"`
+	Players []PlayerGame `json:"players"`
+	// Economy is only filled when requested with ?include=economy.
+	Economy *MapEconomy `json:"economy,omitempty"`
+	// Performance is only filled when requested with ?include=performance.
+	Performance *MapPerformance `json:"performance,omitempty"`
+}
+
+// MapScore is a team's round count on a map, split by side.
+type MapScore struct {
+	Name     string `json:"name"`
+	Score    *int   `json:"score"`
+	Attack   *int   `json:"attack"`
+	Defense  *int   `json:"defense"`
+	Overtime *int   `json:"overtime"`
+}
+
+// PlayerGame is one scoreboard row, either for a single map or the whole
+// series. Percentages are on a 0-100 scale.
+type PlayerGame struct {
+	PlayerID    int      `json:"player_id,omitempty"`
+	Slug        string   `json:"slug,omitempty"`
+	Name        string   `json:"name"`
+	TeamTag     string   `json:"team_tag"`
+	Team        int      `json:"team"`
+	Agents      []string `json:"agents"`
+	Rating      *float64 `json:"rating"`
+	ACS         *float64 `json:"acs"`
+	Kills       *int     `json:"kills"`
+	Deaths      *int     `json:"deaths"`
+	Assists     *int     `json:"assists"`
+	KDDiff      *int     `json:"kd_diff"`
+	KAST        *float64 `json:"kast"`
+	ADR         *float64 `json:"adr"`
+	HSPercent   *float64 `json:"headshot_percentage"`
+	FirstKills  *int     `json:"first_kills"`
+	FirstDeaths *int     `json:"first_deaths"`
+	FKDiff      *int     `json:"fk_diff"`
+}
+
+// MapRounds is the round history strip of one map.
+type MapRounds struct {
+	GameID int     `json:"game_id"`
+	Map    string  `json:"map"`
+	Team1  string  `json:"team1"`
+	Team2  string  `json:"team2"`
+	Rounds []Round `json:"rounds"`
+}
+
+// Round is a single round of a map. Winner is 1 or 2; Team1Score and
+// Team2Score are the running score after the round.
+type Round struct {
+	Number int `json:"number"`
+	Winner int `json:"winner"`
+	// WinnerSide is "attack" or "defense".
+	WinnerSide string `json:"winner_side"`
+	// WinType is "elimination", "detonated", "defused" or "time".
+	WinType    string `json:"win_type"`
+	Team1Score int    `json:"team1_score"`
+	Team2Score int    `json:"team2_score"`
+}
+
+// MapEconomy is the Economy tab of one map: a buy-type summary per team and
+// the bank and buy of both teams in every round.
+type MapEconomy struct {
+	GameID int            `json:"game_id"`
+	Teams  []TeamEconomy  `json:"teams"`
+	Rounds []EconomyRound `json:"rounds"`
+}
+
+// TeamEconomy summarises how a team fared in each buy bracket. vlr.gg's
+// brackets are eco (0-5k), semi-eco (5-10k), semi-buy (10-20k) and full buy
+// (20k+), measured by the team's total loadout value.
+type TeamEconomy struct {
+	Team      string   `json:"team"`
+	PistolWon *int     `json:"pistol_won"`
+	Eco       BuyStats `json:"eco"`
+	SemiEco   BuyStats `json:"semi_eco"`
+	SemiBuy   BuyStats `json:"semi_buy"`
+	FullBuy   BuyStats `json:"full_buy"`
+}
+
+// BuyStats is the number of rounds played and won in a buy bracket.
+// WinRate is on a 0-100 scale, rounded to one decimal, and null when no
+// rounds were played.
+type BuyStats struct {
+	Played  *int     `json:"played"`
+	Won     *int     `json:"won"`
+	WinRate *float64 `json:"win_rate"`
+}
+
+// EconomyRound is both teams' economy in a single round. Winner is 1 or 2.
+type EconomyRound struct {
+	Number int      `json:"number"`
+	Winner int      `json:"winner"`
+	Team1  RoundBuy `json:"team1"`
+	Team2  RoundBuy `json:"team2"`
+}
+
+// RoundBuy is a team's bank (credits left after buying) and buy type in a
+// round. BuyType is "eco", "semi-eco", "semi-buy" or "full-buy".
+type RoundBuy struct {
+	Bank    *int   `json:"bank"`
+	BuyType string `json:"buy_type"`
+}
+
+// MapPerformance is the Performance tab of one map: head-to-head kill
+// matrices between the players of both teams and per-player multikill and
+// clutch counts.
+type MapPerformance struct {
+	GameID int `json:"game_id"`
+	// Kills, FirstKills and OperatorKills are the all-kills, first-kills and
+	// operator-kills matrices.
+	Kills         KillMatrix          `json:"kills"`
+	FirstKills    KillMatrix          `json:"first_kills"`
+	OperatorKills KillMatrix          `json:"operator_kills"`
+	Players       []PlayerPerformance `json:"players"`
+}
+
+// KillMatrix maps a team 1 player ID to the team 2 player IDs they faced.
+// IDs come from the player links on the Performance tab; players without a
+// link are left out.
+type KillMatrix map[int]map[int]Duel
+
+// Duel is one cell of a kill matrix: how often Player (team 1) killed
+// Opponent (team 2) and was killed by them.
+type Duel struct {
+	Player   string `json:"player"`
+	Opponent string `json:"opponent"`
+	Kills    *int   `json:"kills"`
+	Deaths   *int   `json:"deaths"`
+}
+
+// PlayerPerformance is a player's multikill, clutch and utility counts on a
+// map.
+type PlayerPerformance struct {
+	PlayerID int    `json:"player_id,omitempty"`
+	Name     string `json:"name"`
+	TeamTag  string `json:"team_tag"`
+	Agent    string `json:"agent"`
+	// Multikills counts rounds with 2, 3, 4 and 5 kills, keyed "2k".."5k".
+	Multikills map[string]*int `json:"multikills"`
+	// Clutches counts won clutches keyed "1v1".."1v5".
+	Clutches map[string]*int `json:"clutches"`
+	Econ     *int            `json:"econ"`
+	Plants   *int            `json:"plants"`
+	Defuses  *int            `json:"defuses"`
+}
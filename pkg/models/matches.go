@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+// LiveMatch is a match currently in progress, as shown on the vlr.gg home
+// page and enriched from its match page.
+type LiveMatch struct {
+	MatchID        int      `json:"match_id,omitempty"`
+	MatchSlug      string   `json:"match_slug,omitempty"`
+	Team1          string   `json:"team1"`
+	Team2          string   `json:"team2"`
+	Team1ID        int      `json:"team1_id,omitempty"`
+	Team2ID        int      `json:"team2_id,omitempty"`
+	Country1       *Country `json:"country1"`
+	Country2       *Country `json:"country2"`
+	Team1Logo      string   `json:"team1_logo"`
+	Team2Logo      string   `json:"team2_logo"`
+	Score1         *int     `json:"score1"`
+	Score2         *int     `json:"score2"`
+	Team1RoundCT   *int     `json:"team1_round_ct"`
+	Team1RoundT    *int     `json:"team1_round_t"`
+	Team2RoundCT   *int     `json:"team2_round_ct"`
+	Team2RoundT    *int     `json:"team2_round_t"`
+	MapNumber      *int     `json:"map_number"`
+	CurrentMap     string   `json:"current_map"`
+	TimeUntilMatch string   `json:"time_until_match"`
+	MatchEvent     string   `json:"match_event"`
+	MatchSeries    string   `json:"match_series"`
+	EventID        int      `json:"event_id,omitempty"`
+	// UnixTimestamp is the scheduled start in seconds since the epoch.
+	UnixTimestamp *int64     `json:"unix_timestamp"`
+	StartedAt     *time.Time `json:"started_at"`
+	MatchPage     string     `json:"match_page"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
+
+// ScheduledMatch is an upcoming match from the vlr.gg /matches page.
+type ScheduledMatch struct {
+	MatchID   int      `json:"match_id,omitempty"`
+	MatchSlug string   `json:"match_slug,omitempty"`
+	MatchTime string   `json:"match_time"`
+	Team1     string   `json:"team1"`
+	Team2     string   `json:"team2"`
+	Country1  *Country `json:"country1"`
+	Country2  *Country `json:"country2"`
+	Event     string   `json:"event"`
+	Series    string   `json:"series"`
+	ETA       string   `json:"eta"`
+	// StartsAt is the start time derived from ETA at scrape time.
+	StartsAt  *time.Time `json:"starts_at"`
+	MatchPage string     `json:"match_page"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
+
+// MatchResult is a completed match from the vlr.gg /matches/results pages.
+type MatchResult struct {
+	MatchID       int      `json:"match_id,omitempty"`
+	MatchSlug     string   `json:"match_slug,omitempty"`
+	Team1         string   `json:"team1"`
+	Team2         string   `json:"team2"`
+	Score1        *int     `json:"score1"`
+	Score2        *int     `json:"score2"`
+	Country1      *Country `json:"country1"`
+	Country2      *Country `json:"country2"`
+	TimeCompleted string   `json:"time_completed"`
+	// CompletedAt is derived from the "... ago" label at scrape time.
+	CompletedAt    *time.Time `json:"completed_at"`
+	RoundInfo      string     `json:"round_info"`
+	TournamentName string     `json:"tournament_name"`
+	MatchPage      string     `json:"match_page"`
+	TournamentIcon string     `json:"tournament_icon"`
+	PageNumber     int        `json:"page_number"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
+
+// ResultsMeta describes which result pages were fetched for /vlr/match.
+type ResultsMeta struct {
+	PageRange           string `json:"page_range"`
+	TotalPagesRequested int    `json:"total_pages_requested"`
+	SuccessfulPages     int    `json:"successful_pages"`
+	FailedPages         []int  `json:"failed_pages"`
+	TotalMatches        int    `json:"total_matches"`
+}
@@ -0,0 +1,73 @@
+// Package models defines the JSON response types served by vlrggapi. It lives
+// outside internal/ so Go clients can decode responses into the same structs.
+package models
+
+import "time"
+
+// SegmentsResponse is the {"data": {"status": ..., "segments": [...]}}
+// envelope shared by most endpoints.
+type SegmentsResponse[T any] struct {
+	Data Segments[T] `json:"data"`
+}
+
+// Segments holds the rows of a SegmentsResponse along with the upstream
+// status code.
+type Segments[T any] struct {
+	Status   int          `json:"status"`
+	Segments []T          `json:"segments"`
+	Message  string       `json:"message,omitempty"`
+	Meta     *ResultsMeta `json:"meta,omitempty"`
+	Freshness
+}
+
+// NewSegments wraps items in the standard response envelope.
+func NewSegments[T any](status int, items []T) SegmentsResponse[T] {
+	return SegmentsResponse[T]{Data: Segments[T]{Status: status, Segments: items}}
+}
+
+// Freshness is set on responses served from the background-refreshed store
+// or the live poller: FetchedAt is when the data was scraped from vlr.gg and
+// Age is how many seconds ago that was. Age is filled in as each response is
+// sent, so it stays current when the response comes from a cache.
+type Freshness struct {
+	FetchedAt *time.Time `json:"fetched_at,omitempty"`
+	Age       *int64     `json:"age,omitempty"`
+}
+
+// NewFreshness returns the Freshness of data scraped at fetchedAt.
+func NewFreshness(fetchedAt time.Time) Freshness {
+	fetchedAt = fetchedAt.UTC()
+	return Freshness{FetchedAt: &fetchedAt}
+}
+
+// ErrorResponse is returned with every 4xx/5xx status.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// SiteHealth is the health of a single upstream site.
+type SiteHealth struct {
+	Status     string `json:"status"`
+	StatusCode int    `json:"status_code"`
+}
+
+// Money is a currency amount scraped from a display string such as
+// "$123,456".
+type Money struct {
+	AmountCents int64  `json:"amount_cents"`
+	Currency    string `json:"currency"`
+}
+
+// Country is a country as an ISO 3166-1 alpha-2 code and English name,
+// derived from a vlr.gg flag or country label. Code is empty for the region
+// flags vlr.gg uses in place of a country, such as Europe or International.
+type Country struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Record is a win/loss record scraped from a display string such as "12–3".
+type Record struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+}
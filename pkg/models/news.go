@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// NewsArticle is a single entry from the vlr.gg news feed.
+type NewsArticle struct {
+	ArticleID   int        `json:"article_id,omitempty"`
+	Slug        string     `json:"slug,omitempty"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Date        *time.Time `json:"date"`
+	Author      string     `json:"author"`
+	URLPath     string     `json:"url_path"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// PlayerProfile is a vlr.gg player page.
+type PlayerProfile struct {
+	PlayerID int          `json:"player_id"`
+	Slug     string       `json:"slug,omitempty"`
+	Name     string       `json:"name"`
+	RealName string       `json:"real_name"`
+	Avatar   string       `json:"avatar"`
+	Country  *Country     `json:"country"`
+	URL      string       `json:"url"`
+	Socials  []SocialLink `json:"socials"`
+	// Timespan is the window the agent stats cover: "30d", "60d", "90d" or "all".
+	Timespan      string          `json:"timespan"`
+	CurrentTeams  []TeamStint     `json:"current_teams"`
+	PastTeams     []TeamStint     `json:"past_teams"`
+	Agents        []AgentStatLine `json:"agents"`
+	RecentMatches []RecentMatch   `json:"recent_matches"`
+}
+
+// SocialLink is an external profile linked from a vlr.gg player or team page.
+type SocialLink struct {
+	// Platform is derived from the link host, e.g. "twitter" or "twitch".
+	Platform string `json:"platform"`
+	Handle   string `json:"handle"`
+	URL      string `json:"url"`
+}
+
+// TeamStint is a period a player spent on a team. vlr.gg only shows the
+// month, so Joined and Left are the first day of that month.
+type TeamStint struct {
+	TeamID int        `json:"team_id,omitempty"`
+	Slug   string     `json:"slug,omitempty"`
+	Name   string     `json:"name"`
+	Logo   string     `json:"logo"`
+	Role   string     `json:"role,omitempty"`
+	Joined *time.Time `json:"joined"`
+	Left   *time.Time `json:"left"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
+
+// AgentStatLine is one row of a player's agent table. The stat columns use
+// the same names and scales as PlayerStatLine.
+type AgentStatLine struct {
+	Agent                     string   `json:"agent"`
+	UsageCount                *int     `json:"usage_count"`
+	UsagePercentage           *float64 `json:"usage_percentage"`
+	RoundsPlayed              *int     `json:"rounds_played"`
+	Rating                    *float64 `json:"rating"`
+	AverageCombatScore        *float64 `json:"average_combat_score"`
+	KillDeaths                *float64 `json:"kill_deaths"`
+	AverageDamagePerRound     *float64 `json:"average_damage_per_round"`
+	KillAssistsSurvivedTraded *float64 `json:"kill_assists_survived_traded"`
+	KillsPerRound             *float64 `json:"kills_per_round"`
+	AssistsPerRound           *float64 `json:"assists_per_round"`
+	FirstKillsPerRound        *float64 `json:"first_kills_per_round"`
+	FirstDeathsPerRound       *float64 `json:"first_deaths_per_round"`
+	Kills                     *int     `json:"kills"`
+	Deaths                    *int     `json:"deaths"`
+	Assists                   *int     `json:"assists"`
+	FirstKills                *int     `json:"first_kills"`
+	FirstDeaths               *int     `json:"first_deaths"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
+
+// RecentMatch is an entry of a player's or team's match history. Team1 is
+// the player's (or the page's) team.
+type RecentMatch struct {
+	MatchID   int    `json:"match_id,omitempty"`
+	MatchSlug string `json:"match_slug,omitempty"`
+	Event     string `json:"event"`
+	Series    string `json:"series"`
+	Team1     string `json:"team1"`
+	Team2     string `json:"team2"`
+	Score1    *int   `json:"score1"`
+	Score2    *int   `json:"score2"`
+	// Result is "win", "loss" or empty for draws and unplayed matches.
+	Result    string     `json:"result"`
+	Date      *time.Time `json:"date"`
+	MatchPage string     `json:"match_page"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// RankingsResponse is the envelope returned by /vlr/rankings.
+type RankingsResponse struct {
+	Status int       `json:"status"`
+	Data   []Ranking `json:"data"`
+	Freshness
+}
+
+// Ranking is one team row of a regional ranking table.
+type Ranking struct {
+	Rank               *int       `json:"rank"`
+	Team               string     `json:"team"`
+	TeamID             int        `json:"team_id,omitempty"`
+	TeamSlug           string     `json:"team_slug,omitempty"`
+	TeamURL            string     `json:"team_url,omitempty"`
+	Country            *Country   `json:"country"`
+	LastPlayed         *time.Time `json:"last_played"`
+	LastPlayedTeam     string     `json:"last_played_team"`
+	LastPlayedTeamLogo string     `json:"last_played_team_logo"`
+	LastPlayedMatchID  int        `json:"last_played_match_id,omitempty"`
+	Record             *Record    `json:"record"`
+	Earnings           *Money     `json:"earnings"`
+	Logo               string     `json:"logo"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
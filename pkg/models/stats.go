@@ -0,0 +1,26 @@
+package models
+
+// PlayerStatLine is one player row of the vlr.gg stats table. Percentages
+// are on a 0-100 scale.
+type PlayerStatLine struct {
+	Player                    string   `json:"player"`
+	PlayerID                  int      `json:"player_id,omitempty"`
+	PlayerSlug                string   `json:"player_slug,omitempty"`
+	Org                       string   `json:"org"`
+	TeamID                    int      `json:"team_id,omitempty"`
+	Agents                    []string `json:"agents"`
+	RoundsPlayed              *int     `json:"rounds_played"`
+	Rating                    *float64 `json:"rating"`
+	AverageCombatScore        *float64 `json:"average_combat_score"`
+	KillDeaths                *float64 `json:"kill_deaths"`
+	KillAssistsSurvivedTraded *float64 `json:"kill_assists_survived_traded"`
+	AverageDamagePerRound     *float64 `json:"average_damage_per_round"`
+	KillsPerRound             *float64 `json:"kills_per_round"`
+	AssistsPerRound           *float64 `json:"assists_per_round"`
+	FirstKillsPerRound        *float64 `json:"first_kills_per_round"`
+	FirstDeathsPerRound       *float64 `json:"first_deaths_per_round"`
+	HeadshotPercentage        *float64 `json:"headshot_percentage"`
+	ClutchSuccessPercentage   *float64 `json:"clutch_success_percentage"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
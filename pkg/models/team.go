@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// TeamProfile is a vlr.gg team page.
+type TeamProfile struct {
+	TeamID  int          `json:"team_id"`
+	Slug    string       `json:"slug,omitempty"`
+	Name    string       `json:"name"`
+	Tag     string       `json:"tag"`
+	Logo    string       `json:"logo"`
+	Country *Country     `json:"country"`
+	URL     string       `json:"url"`
+	Socials []SocialLink `json:"socials"`
+	// Region is the ranking region the team is listed under, e.g. "Europe".
+	Region        string         `json:"region"`
+	Rank          *int           `json:"rank"`
+	Rating        *int           `json:"rating"`
+	TotalWinnings *Money         `json:"total_winnings"`
+	Roster        []RosterMember `json:"roster"`
+	// RecentResults are completed matches, newest first; Team1 is this team.
+	RecentResults []RecentMatch `json:"recent_results"`
+	// UpcomingMatches are scheduled matches without a score yet.
+	UpcomingMatches []RecentMatch `json:"upcoming_matches"`
+	// RatingHistory is the team's rating after each completed match, newest
+	// first.
+	RatingHistory []RatingPoint `json:"rating_history"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
+
+// RosterMember is a player or staff member on a team page. Role is "player"
+// for active players and otherwise the role vlr.gg shows in lowercase, e.g.
+// "sub", "inactive", "head coach" or "manager".
+type RosterMember struct {
+	PlayerID int      `json:"player_id,omitempty"`
+	Slug     string   `json:"slug,omitempty"`
+	Alias    string   `json:"alias"`
+	RealName string   `json:"real_name"`
+	Country  *Country `json:"country"`
+	Avatar   string   `json:"avatar"`
+	Role     string   `json:"role"`
+	Captain  bool     `json:"captain"`
+	Staff    bool     `json:"staff"`
+}
+
+// RatingPoint is a team's vlr.gg rating after one completed match and the
+// change that match made to it.
+type RatingPoint struct {
+	MatchID int        `json:"match_id,omitempty"`
+	Date    *time.Time `json:"date"`
+	Rating  *int       `json:"rating"`
+	Change  *int       `json:"change"`
+	// Raw holds the original scraped strings of typed fields, keyed by JSON name.
+	Raw map[string]string `json:"raw"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Webhook is a registered receiver of match lifecycle notifications. A
+// webhook with no team, event or region filter receives every match; with
+// filters, a match is sent if it matches any of them.
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Events are the notification types to send: "match_started",
+	// "map_ended" and/or "match_ended".
+	Events   []string `json:"events"`
+	TeamIDs  []int    `json:"team_ids"`
+	EventIDs []int    `json:"event_ids"`
+	// Regions are region keys as accepted by /vlr/rankings, e.g. "eu". A
+	// match is in a region if either team is ranked there.
+	Regions []string `json:"regions"`
+	// Secret signs every delivery. It is only returned when the webhook is
+	// created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookRequest is the body of a webhook registration. Events defaults to
+// all lifecycle events and Secret to a random value.
+type WebhookRequest struct {
+	URL      string   `json:"url"`
+	Events   []string `json:"events"`
+	TeamIDs  []int    `json:"team_ids"`
+	EventIDs []int    `json:"event_ids"`
+	Regions  []string `json:"regions"`
+	Secret   string   `json:"secret"`
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook.
+type WebhookPayload struct {
+	// ID identifies the notification; retries of it reuse the same ID.
+	ID        string    `json:"id"`
+	WebhookID string    `json:"webhook_id"`
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	// Data is the live event that triggered the notification; it is null
+	// for "ping".
+	Data *LiveEvent `json:"data"`
+}
+
+// WebhookDelivery is one attempt at POSTing a notification, as kept in a
+// webhook's delivery log.
+type WebhookDelivery struct {
+	ID      string `json:"id"`
+	Event   string `json:"event"`
+	Attempt int    `json:"attempt"`
+	// StatusCode is the receiver's response status, 0 if none was received.
+	StatusCode int       `json:"status_code"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	At         time.Time `json:"at"`
+	// NextRetry is when the notification will be tried again, if it will.
+	NextRetry *time.Time `json:"next_retry,omitempty"`
+}